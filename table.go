@@ -2,7 +2,8 @@
 // New and providing a table header. Table rows can be added with AddRow. The table visualization can be
 // altered with SetGrid and SetPadding. The package provides a set of grids or a grid can be customized.
 // The string representation of a table is retrieved with Print. A table is sorted alphabetically by the
-// first column. It can be sorted by other columns with SortBy.
+// first column. It can be sorted by other columns with SortBy and SortByKeys or kept in insertion order with
+// SortByInsertion.
 //
 // Copyright (c) 2023 thorstenrie.
 // All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
@@ -11,7 +12,7 @@ package tstable
 
 // Import Go standard packages, lpstats, tsfio and tserr
 import (
-	"strings"      // strings
+	"io"           // io
 	"unicode/utf8" // utf8
 
 	// lpstats
@@ -19,16 +20,19 @@ import (
 	"github.com/thorstenrie/tsfio" // tsfio
 )
 
-// Table holds the header of the table and all rows of the table. It also contains
-// information on the width of each column, the row index for sorting, padding and the table grid.
-// Per default, a table has padding 2, a simple grid and is sorted by its first row.
+// Table holds the header of the table and all rows of the table. It also contains information on the width,
+// alignment and sort type of each column, the columns for sorting, padding and the table grid. The rows are kept in
+// insertion order.
+// Per default, a table has padding 2, a simple grid, left-aligned columns and is sorted by its first row.
 type Table struct {
-	header  []string   // Header as a slice of strings
-	rows    [][]string // Rows as a slice of slices of strings
-	width   []int      // Width of each row
-	key     int        // Row index for sorting (default first column)
-	padding int        // Padding (default 2)
-	grid    *Grid      // Table grid
+	header  []string     // Header as a slice of strings
+	rows    [][]string   // Rows as a slice of slices of strings
+	width   []int        // Width of each row
+	align   []Alignment  // Alignment of each column
+	keys    []sortColumn // Columns for sorting (default first column)
+	sorts   []SortArgs   // Sort type of each column
+	padding int          // Padding (default 2)
+	grid    *Grid        // Table grid
 }
 
 // New returns a pointer to a new Table. It expects the header of the table
@@ -51,12 +55,14 @@ func New(h []string) (*Table, error) {
 	}
 	// Retrieve a new instance of struct Table
 	t := &Table{
-		padding: 2,                   // default padding
-		grid:    &SimpleGrid,         // with a simple table grid
-		header:  h,                   // set header
-		rows:    make([][]string, 0), // allocate and initialize rows
-		width:   make([]int, len(h)), // allocate and initialize width
-		key:     0,                   // set sort key to first column
+		padding: 2,                         // default padding
+		grid:    &SimpleGrid,               // with a simple table grid
+		header:  h,                         // set header
+		rows:    make([][]string, 0),       // allocate and initialize rows
+		width:   make([]int, len(h)),       // allocate and initialize width
+		align:   make([]Alignment, len(h)), // allocate and initialize alignment (left)
+		sorts:   make([]SortArgs, len(h)),  // allocate and initialize sort types (string)
+		keys:    []sortColumn{{}},          // set sort key to first column
 	}
 	// Iterate over elements of h
 	for i, c := range h {
@@ -130,113 +136,55 @@ func (t *Table) String() string {
 
 // Print returns the contents of table t in a string representation. The formatting
 // of the table can be altered by changing the padding with SetPadding or setting a different grid with SetGrid.
-// The rows are sorted in alphabetical order according to the selected column with
-// SortBy. Per default, it is sorted by the first column.
+// The rows are sorted according to the columns selected with SortBy or SortByKeys and their sort types. Per default,
+// it is sorted by the first column in alphabetical order. Print does not modify table t, the rows of t keep their
+// insertion order.
 func (t *Table) Print() (string, error) {
-	// Initialize return value with an empty string
-	text := ""
-	// Return an empty string and an error, if t is nil
-	if t == nil {
-		return text, tserr.NilPtr()
-	}
-	// Return an empty string and an error, if header or rows are nil
-	if (t.header == nil) || (t.rows == nil) {
-		return text, tserr.NilPtr()
-	}
-	// Return an empty string and an error, if the number of elements in header does not equal the number of elements in width
-	if len(t.header) != len(t.width) {
-		return text, tserr.Equal(&tserr.EqualArgs{Var: "table width slice", Actual: int64(len(t.width)), Want: int64(len(t.header))})
-	}
-	// Retrieve spaces for padding
-	spaces, e := t.spaces()
-	// Return an empty string and an error, if spaces returns an error
-	if e != nil {
-		return text, tserr.Op(&tserr.OpArgs{Op: "spaces", Fn: "table", Err: e})
-	}
-	// Sort table by selected row, which is given by the row index in struct field key
-	if err := t.sort(); err != nil {
-		// Return an empty string and an error, if sorting fails
-		return text, tserr.Op(&tserr.OpArgs{Op: "sort", Fn: "table", Err: err})
-	}
-	// Retrieve top horizontal grid line
-	hline, e := t.hline(0)
-	// Return an empty string and an error, if hline fails
-	if e != nil {
-		return text, tserr.Op(&tserr.OpArgs{Op: "hline", Fn: "table", Err: e})
-	}
-	// Add top horizontal grid line to string
-	text += hline
-	// Print header
-	for i, h := range t.header {
-		// Return an empty string and an error, if the difference of width of column i and length of h is negative
-		if t.width[i]-len(h) < 0 {
-			return "", tserr.Higher(&tserr.HigherArgs{Var: "width", Actual: int64(t.width[i]), LowerBound: int64(len(h))})
-		}
-		// Retrieve top vertical grid line
-		vline, e := t.vline(i)
-		// Return an empty string and an error, if vline fails
-		if e != nil {
-			return "", tserr.Op(&tserr.OpArgs{Op: "vline", Fn: "table", Err: e})
-		}
-		// Add header of column to return string
-		text += vline + spaces + h + strings.Repeat(" ", t.width[i]-len(h))
-	}
-	// Retrieve vertical grid line at the end of the header
-	vrline, e := t.vline(len(t.header))
-	// Return an empty string and an error, if vline fails
+	// Retrieve view of t with rows sorted by the sort keys
+	v, e := t.view()
+	// Return an empty string and an error, if view fails
 	if e != nil {
-		return "", tserr.Op(&tserr.OpArgs{Op: "vline", Fn: "table", Err: e})
+		return "", tserr.Op(&tserr.OpArgs{Op: "view", Fn: "table", Err: e})
 	}
-	// Add vertical grid line at the end of the header to the return string
-	text += vrline + "\n"
-	// Retrieve horizontal grid line below header
-	hline, e = t.hline(1)
-	// Return an empty string and an error, if hline fails
+	// Return the box representation of the view
+	return v.box()
+}
+
+// WriteTo writes the contents of table t in the string representation of Print to w. It implements io.WriterTo.
+// In contrast to Print, the table is written in chunks without retaining the whole string representation.
+// The rows are sorted in the same order as with Print. It returns the number of bytes written and an error, if any.
+// Errors of w are returned wrapped.
+func (t *Table) WriteTo(w io.Writer) (int64, error) {
+	// Return zero and an error, if w is nil
+	if w == nil {
+		return 0, tserr.NilPtr()
+	}
+	// Retrieve view of t with rows sorted by the sort keys
+	v, e := t.view()
+	// Return zero and an error, if view fails
 	if e != nil {
-		return "", tserr.Op(&tserr.OpArgs{Op: "hline", Fn: "table", Err: e})
+		return 0, tserr.Op(&tserr.OpArgs{Op: "view", Fn: "table", Err: e})
 	}
-	// Add horizontal grid line to return string
-	text += hline
-	// Return string representation if the table does not have rows
-	if len(t.rows) == 0 {
-		return text, nil
-	}
-	// Print rows
-	for _, r := range t.rows {
-		// Return an empty string and an error, if the size of row r is not equal to the size of width
-		if len(t.width) != len(r) {
-			return "", tserr.Higher(&tserr.HigherArgs{Var: "sizte of row", Actual: int64(len(r)), LowerBound: int64(len(t.width))})
-		}
-		// Print row r
-		for j, c := range r {
-			// Return an empty string and an error, if the difference of width of column j and length of c is negative
-			if t.width[j]-len(c) < 0 {
-				return "", tserr.Higher(&tserr.HigherArgs{Var: "width", Actual: int64(t.width[j]), LowerBound: int64(len(c))})
-			}
-			// Retrieve top vertical grid line
-			vline, e := t.vline(j)
-			// Return an empty string and an error, if vline fails
-			if e != nil {
-				return text, tserr.Op(&tserr.OpArgs{Op: "vline", Fn: "table", Err: e})
-			}
-			// Add cell c of row r to return string
-			text += vline + spaces + c + strings.Repeat(" ", t.width[j]-len(c))
-		}
-		// Add vertical grid line to return string and start new row
-		text += vrline + "\n"
-	}
-	// Retrieve bottom horizontal grid line
-	hline, e = t.hline(len(t.rows) + 1)
-	// Return an empty string and an error, if hline fails
+	// Write the box representation of the view to w
+	return v.boxTo(w)
+}
+
+// Fprint writes the contents of table t in the string representation of Print to w. It returns the number of bytes
+// written and an error, if any.
+func Fprint(w io.Writer, t *Table) (int, error) {
+	// Write table t to w
+	n, e := t.WriteTo(w)
+	// Return the number of bytes written and an error, if WriteTo fails
 	if e != nil {
-		return text, tserr.Op(&tserr.OpArgs{Op: "hline", Fn: "table", Err: e})
+		return int(n), tserr.Op(&tserr.OpArgs{Op: "WriteTo", Fn: "table", Err: e})
 	}
-	// Add horizontal grid line to return string
-	return text + hline, nil
+	// Return the number of bytes written
+	return int(n), nil
 }
 
-// SortBy sets table t to be sorted by column header h. When printing the table, the table will be sorted by column with header h.
-// It returns an error if column header h is empty or cannot be found in the table t.
+// SortBy sets table t to be sorted ascending by column header h. When printing the table, the table will be sorted by
+// column with header h. It equals SortByKeys with a single ascending key. It returns an error if column header h is
+// empty or cannot be found in the table t.
 func (t *Table) SortBy(h string) error {
 	// Return an error, if t is bil
 	if t == nil {
@@ -252,7 +200,7 @@ func (t *Table) SortBy(h string) error {
 		return tserr.Op(&tserr.OpArgs{Op: "find", Fn: h, Err: e})
 	}
 	// Set sort key to index i
-	t.key = i
+	t.keys = []sortColumn{{index: i}}
 	// Return nil
 	return nil
 }
@@ -0,0 +1,67 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable
+
+// Import Go standard packages and tserr
+import (
+	"strings"      // strings
+	"unicode/utf8" // utf8
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// An Alignment defines the horizontal alignment of the cells of a column.
+type Alignment int
+
+const (
+	AlignLeft   Alignment = iota // Left-aligned column (default)
+	AlignRight                   // Right-aligned column
+	AlignCenter                  // Centered column
+)
+
+// SetAlignment sets the alignment of column with header h to a. Per default, all columns are left-aligned.
+// It returns an error if column header h cannot be found in table t or if a is not a valid alignment.
+func (t *Table) SetAlignment(h string, a Alignment) error {
+	// Return an error if t is nil
+	if t == nil {
+		return tserr.NilPtr()
+	}
+	// Return an error if a is not a valid alignment
+	if (a < AlignLeft) || (a > AlignCenter) {
+		return tserr.Lower(&tserr.LowerArgs{Var: "alignment", Actual: int64(a), HigherBound: int64(AlignCenter) + 1})
+	}
+	// Retrieve index i of column header h
+	i, e := t.find(h)
+	// Return an error if find returns an error
+	if e != nil {
+		return tserr.Op(&tserr.OpArgs{Op: "find", Fn: h, Err: e})
+	}
+	// Return an error if the number of alignments does not equal the number of elements of the table header
+	if len(t.align) != len(t.header) {
+		return tserr.Equal(&tserr.EqualArgs{Var: "alignments", Actual: int64(len(t.align)), Want: int64(len(t.header))})
+	}
+	// Set alignment of column i to a
+	t.align[i] = a
+	// Return nil
+	return nil
+}
+
+// pad returns cell c padded with spaces to width w with alignment a. If c has w or more runes, c is returned.
+func pad(c string, w int, a Alignment) string {
+	// Number of spaces to fill c up to width w
+	n := w - utf8.RuneCountInString(c)
+	// Return c if no spaces are needed
+	if n <= 0 {
+		return c
+	}
+	// Pad c according to alignment a
+	switch a {
+	case AlignRight:
+		return strings.Repeat(" ", n) + c
+	case AlignCenter:
+		return strings.Repeat(" ", n/2) + c + strings.Repeat(" ", n-n/2)
+	default:
+		return c + strings.Repeat(" ", n)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable
+
+// Import Go standard package strings and tserr
+import (
+	"strings" // strings
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// asciidocSpec maps a column alignment to its horizontal alignment operator in a cols specification
+var asciidocSpec = map[Alignment]string{
+	AlignLeft:   "<",
+	AlignRight:  ">",
+	AlignCenter: "^",
+}
+
+// AsciiDoc returns table t as an AsciiDoc table delimited by |===. The cols specification is derived from the
+// column alignment and the first row is marked as header. The cell separator | is escaped in cells.
+// The rows are sorted in the same order as with Print. It returns an empty string and an error, if any.
+func (t *Table) AsciiDoc() (string, error) {
+	// Retrieve view of t
+	v, e := t.view()
+	// Return an empty string and an error if view fails
+	if e != nil {
+		return "", tserr.Op(&tserr.OpArgs{Op: "view", Fn: "table", Err: e})
+	}
+	// Return AsciiDoc representation
+	return v.asciidoc()
+}
+
+// asciidoc returns view v as an AsciiDoc table.
+func (v *View) asciidoc() (string, error) {
+	// Derive cols specification from column alignment
+	cols := make([]string, len(v.header))
+	for i := range v.header {
+		cols[i] = asciidocSpec[v.Alignment(i)]
+	}
+	var b strings.Builder
+	// Write block attributes and opening delimiter
+	b.WriteString(`[cols="` + strings.Join(cols, ",") + `",options="header"]` + "\n|===\n")
+	// Write header followed by an empty line
+	asciidocRow(&b, v.header)
+	b.WriteString("\n")
+	// Write rows
+	for _, r := range v.rows {
+		asciidocRow(&b, r)
+	}
+	// Write closing delimiter
+	b.WriteString("|===\n")
+	// Return AsciiDoc representation
+	return b.String(), nil
+}
+
+// asciidocRow writes row r with escaped cells to b.
+func asciidocRow(b *strings.Builder, r []string) {
+	for i, c := range r {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString("|" + strings.ReplaceAll(c, "|", `\|`))
+	}
+	b.WriteString("\n")
+}
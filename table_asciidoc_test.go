@@ -0,0 +1,46 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable_test
+
+// Import Go standard library package testing as well as tstable and tserr
+import (
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tstable" // tstable
+)
+
+// TestAsciiDoc tests the AsciiDoc representation of the test table with a right-aligned and a centered column.
+// The test fails if the AsciiDoc representation does not equal to the contents of the test data golden file.
+func TestAsciiDoc(t *testing.T) {
+	// Retrieve AsciiDoc representation of test table with aligned columns
+	s, e := testAlignedTable(t).AsciiDoc()
+	// The test fails if AsciiDoc returns an error
+	if e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "AsciiDoc", Fn: "table", Err: e}))
+	}
+	// Evaluate the AsciiDoc representation
+	evalGolden("AsciiDoc", s, t)
+}
+
+// TestAsciiDocEscape tests escaping of the cell separator. The test fails if the escaped cell does not match.
+func TestAsciiDocEscape(t *testing.T) {
+	// Retrieve new test table
+	tbl, e := tstable.New([]string{"a|b"})
+	// The test fails if New returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "New", Fn: "table", Err: e}))
+	}
+	// Retrieve AsciiDoc representation
+	s, e := tbl.AsciiDoc()
+	// The test fails if AsciiDoc returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "AsciiDoc", Fn: "table", Err: e}))
+	}
+	// The test fails if the AsciiDoc representation does not match
+	want := "[cols=\"<\",options=\"header\"]\n|===\n|a\\|b\n\n|===\n"
+	if s != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "AsciiDoc", Actual: s, Want: want}))
+	}
+}
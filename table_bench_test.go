@@ -0,0 +1,78 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable_test
+
+// Import Go standard library packages as well as tstable and tserr
+import (
+	"io"      // io
+	"strconv" // strconv
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tstable" // tstable
+)
+
+// benchTable returns a table with n rows and three columns, of which one is right-aligned.
+func benchTable(b *testing.B, n int) *tstable.Table {
+	// Retrieve new table
+	tbl, e := tstable.New([]string{"Host", "Load", "Status"})
+	// The benchmark fails if New returns an error
+	if e != nil {
+		b.Fatal(tserr.Op(&tserr.OpArgs{Op: "New", Fn: "table", Err: e}))
+	}
+	// Add n rows
+	for i := 0; i < n; i++ {
+		if e := tbl.AddRow([]string{"node" + strconv.Itoa(i), strconv.Itoa(i % 100), "running"}); e != nil {
+			b.Fatal(tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: "table", Err: e}))
+		}
+	}
+	// Right-align column Load
+	if e := tbl.SetAlignment("Load", tstable.AlignRight); e != nil {
+		b.Fatal(tserr.Op(&tserr.OpArgs{Op: "SetAlignment", Fn: "table", Err: e}))
+	}
+	// Return table
+	return tbl
+}
+
+// benchPrint benchmarks Print of a table with n rows.
+func benchPrint(b *testing.B, n int) {
+	tbl := benchTable(b, n)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, e := tbl.Print(); e != nil {
+			b.Fatal(tserr.Op(&tserr.OpArgs{Op: "Print", Fn: "table", Err: e}))
+		}
+	}
+}
+
+// benchWriteTo benchmarks WriteTo of a table with n rows.
+func benchWriteTo(b *testing.B, n int) {
+	tbl := benchTable(b, n)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, e := tbl.WriteTo(io.Discard); e != nil {
+			b.Fatal(tserr.Op(&tserr.OpArgs{Op: "WriteTo", Fn: "table", Err: e}))
+		}
+	}
+}
+
+// BenchmarkPrint10 benchmarks Print of a table with 10 rows.
+func BenchmarkPrint10(b *testing.B) { benchPrint(b, 10) }
+
+// BenchmarkPrint10k benchmarks Print of a table with 10,000 rows.
+func BenchmarkPrint10k(b *testing.B) { benchPrint(b, 10_000) }
+
+// BenchmarkPrint1M benchmarks Print of a table with 1,000,000 rows.
+func BenchmarkPrint1M(b *testing.B) { benchPrint(b, 1_000_000) }
+
+// BenchmarkWriteTo10 benchmarks WriteTo of a table with 10 rows.
+func BenchmarkWriteTo10(b *testing.B) { benchWriteTo(b, 10) }
+
+// BenchmarkWriteTo10k benchmarks WriteTo of a table with 10,000 rows.
+func BenchmarkWriteTo10k(b *testing.B) { benchWriteTo(b, 10_000) }
+
+// BenchmarkWriteTo1M benchmarks WriteTo of a table with 1,000,000 rows.
+func BenchmarkWriteTo1M(b *testing.B) { benchWriteTo(b, 1_000_000) }
@@ -0,0 +1,280 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable
+
+// Import Go standard packages as well as tserr and tsfio
+import (
+	"io"      // io
+	"slices"  // slices
+	"strconv" // strconv
+	"strings" // strings
+
+	"github.com/thorstenrie/tserr" // tserr
+	"github.com/thorstenrie/tsfio" // tsfio
+)
+
+// ReadBoxArgs holds the arguments for reading a table in the box representation of Print. Empty fields are set
+// to default values.
+//
+//	Grid:		grid of the box representation, if nil the grid is detected from AllGrids
+//	NonPrintable:	handling of non-printable runes in cells (default NonPrintableError)
+type ReadBoxArgs struct {
+	Grid         *Grid        // Grid of the box representation
+	NonPrintable NonPrintable // Policy for non-printable runes
+}
+
+// ReadBox returns a new Table read from r in the box representation of Print. The box is expected to be drawn with
+// the Grid in a. If the Grid in a is nil, the grid is detected from the grids in AllGrids. The header and the rows
+// are reconstructed with the padding of the cells trimmed. The returned table has the grid and the padding of the
+// box representation. The alignment of the columns is not reconstructed. Cells must not contain the vertical
+// grid line runes of the grid. With a grid without inner vertical grid lines, like the EmptyGrid, columns are
+// separated by at least twice the padding of spaces, which requires a padding of at least one. If a is nil,
+// default values are used. It returns nil and an error, if the box representation does not match the grid,
+// if no grid of AllGrids matches or if a row cannot be added to the table.
+func ReadBox(r io.Reader, a *ReadBoxArgs) (*Table, error) {
+	// Return nil and an error if r is nil
+	if r == nil {
+		return nil, tserr.NilPtr()
+	}
+	// Use default arguments, if a is nil
+	if a == nil {
+		a = &ReadBoxArgs{}
+	}
+	// Read box representation
+	b, e := io.ReadAll(r)
+	// Return nil and an error, if ReadAll fails
+	if e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "ReadAll", Fn: "box", Err: e})
+	}
+	// Split the box representation into lines without the final newline
+	s := strings.TrimSuffix(strings.ReplaceAll(string(b), "\r\n", "\n"), "\n")
+	l := strings.Split(s, "\n")
+	// Retrieve grid of the box representation
+	g := a.Grid
+	if g == nil {
+		if g = boxGrid(l); g == nil {
+			return nil, tserr.TypeNotMatching(&tserr.TypeNotMatchingArgs{Act: "box", Want: "box drawn with a grid of AllGrids"})
+		}
+	}
+	// Return table read from lines l
+	return readBox(l, g, a.NonPrintable)
+}
+
+// boxGrid returns the grid of AllGrids matching the box representation in lines l. Grids are matched in the
+// order of their names with the EmptyGrid last. It returns nil, if no grid matches.
+func boxGrid(l []string) *Grid {
+	// Retrieve sorted names of the grids
+	names := make([]string, 0, len(AllGrids))
+	for n := range AllGrids {
+		if AllGrids[n] != &EmptyGrid {
+			names = append(names, n)
+		}
+	}
+	slices.Sort(names)
+	// Return the first grid matching the box representation
+	for _, n := range names {
+		if AllGrids[n].boxMatch(l) == nil {
+			return AllGrids[n]
+		}
+	}
+	// Return the EmptyGrid, if it matches the box representation
+	if EmptyGrid.boxMatch(l) == nil {
+		return &EmptyGrid
+	}
+	// Return nil
+	return nil
+}
+
+// boxMatch returns an error, if the horizontal grid lines or the vertical border grid lines of the box
+// representation in lines l do not match grid g.
+func (g *Grid) boxMatch(l []string) error {
+	// Return an error, if the number of lines is too low for a box
+	n := len(l)
+	if n < 3 {
+		return tserr.Higher(&tserr.HigherArgs{Var: "number of lines", Actual: int64(n), LowerBound: 3})
+	}
+	// Return an error, if the box has rows without a bottom horizontal grid line
+	if n == 4 {
+		return tserr.Higher(&tserr.HigherArgs{Var: "number of lines", Actual: int64(n), LowerBound: 5})
+	}
+	// Lines of the horizontal grid lines. The horizontal grid line below the header is the bottom line, if the
+	// box does not have rows.
+	h := map[int]int{0: boxTop, 2: boxBottom}
+	if n > 3 {
+		h[2], h[n-1] = boxMid, boxBottom
+	}
+	// Evaluate each line
+	for i, s := range l {
+		// Retrieve the first rune, the last rune and the inner runes of the line
+		k, hl := h[i]
+		first, last, inner := g.Vb, g.Vb, []rune{g.Vi}
+		if hl {
+			first, last, inner = g.boxRunes(k)
+		}
+		// Return an error, if the line does not match the grid runes
+		if !boxLine(s, first, last, inner, hl) {
+			return tserr.TypeNotMatching(&tserr.TypeNotMatchingArgs{Act: "line " + strconv.Itoa(i+1), Want: "grid line"})
+		}
+	}
+	// Return nil
+	return nil
+}
+
+// boxRunes returns the first rune, the last rune and the inner runes of horizontal grid line l of grid g,
+// which is one of boxTop, boxMid or boxBottom.
+func (g *Grid) boxRunes(l int) (rune, rune, []rune) {
+	switch l {
+	case boxTop:
+		return g.Hvtl, g.Hvtr, []rune{g.Hb, g.Hvt}
+	case boxBottom:
+		return g.Hvbl, g.Hvbr, []rune{g.Hb, g.Hvb}
+	default:
+		return g.Hvl, g.Hvr, []rune{g.Hi, g.Hvi}
+	}
+}
+
+// boxLine returns true, if the first and the last rune of line s other than spaces are first and last. A zero
+// rune matches any rune. If hline is true, s must only contain spaces, first, last and inner runes.
+func boxLine(s string, first, last rune, inner []rune, hline bool) bool {
+	// Trim spaces of line s
+	r := []rune(strings.Trim(s, " "))
+	// Return false, if the first or the last rune does not match
+	if (first != 0) && ((len(r) == 0) || (r[0] != first)) {
+		return false
+	}
+	if (last != 0) && ((len(r) == 0) || (r[len(r)-1] != last)) {
+		return false
+	}
+	// Return true, if s is not a horizontal grid line
+	if !hline {
+		return true
+	}
+	// Return false, if a rune of a horizontal grid line does not match
+	for _, u := range r {
+		if (u != ' ') && (u != first) && (u != last) && !slices.Contains(inner, u) {
+			return false
+		}
+	}
+	// Return true
+	return true
+}
+
+// readBox returns a new Table read from the box representation in lines l drawn with grid g. Non-printable runes
+// are handled according to policy p. It returns nil and an error, if l does not match g or if a row cannot be
+// added to the table.
+func readBox(l []string, g *Grid, p NonPrintable) (*Table, error) {
+	// Return nil and an error, if the lines do not match grid g
+	if e := g.boxMatch(l); e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "boxMatch", Fn: "grid", Err: e})
+	}
+	// Retrieve padding from the leading spaces of the top horizontal grid line
+	pad := len(l[0]) - len(strings.TrimLeft(l[0], " "))
+	// Retrieve content lines of the header and the rows without the outer padding and vertical border grid lines
+	c := make([]string, 0, len(l))
+	for i, s := range l {
+		if (i == 1) || ((i > 2) && (i < len(l)-1)) {
+			s = strings.TrimPrefix(strings.TrimPrefix(s, strings.Repeat(" ", pad)), tsfio.RuneToPrintable(g.Vb))
+			c = append(c, strings.TrimSuffix(strings.TrimRight(s, " "), tsfio.RuneToPrintable(g.Vb)))
+		}
+	}
+	// Retrieve cells of the content lines
+	var (
+		cells [][]string
+		e     error
+	)
+	if g.Vi != 0 {
+		cells, e = boxSplit(c, tsfio.RuneToPrintable(g.Vi))
+	} else {
+		cells, e = boxColumns(c, pad)
+	}
+	// Return nil and an error, if the cells cannot be retrieved
+	if e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "cells", Fn: "box", Err: e})
+	}
+	// Retrieve new table with the header
+	t, e := newImport(cells[0], p)
+	if e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "newImport", Fn: "box", Err: e})
+	}
+	// Add rows
+	for i, r := range cells[1:] {
+		if e := t.addImport(r, p); e != nil {
+			return nil, tserr.Op(&tserr.OpArgs{Op: "addImport", Fn: "box line " + strconv.Itoa(i+4), Err: e})
+		}
+	}
+	// Set grid and padding of the box representation
+	t.grid, t.padding = g, pad
+	// Return table
+	return t, nil
+}
+
+// boxSplit returns the cells of content lines c separated by the vertical grid line vi. The cells are trimmed of
+// spaces. It returns nil and an error, if the number of cells of a row does not equal the number of cells of the header.
+func boxSplit(c []string, vi string) ([][]string, error) {
+	// Retrieve cells of each line
+	cells := make([][]string, len(c))
+	for i, s := range c {
+		cells[i] = strings.Split(s, vi)
+		for j := range cells[i] {
+			cells[i][j] = strings.Trim(cells[i][j], " ")
+		}
+		// Return nil and an error, if the number of cells does not equal the number of cells of the header
+		if len(cells[i]) != len(cells[0]) {
+			return nil, tserr.Equal(&tserr.EqualArgs{Var: "cells of row " + strconv.Itoa(i), Actual: int64(len(cells[i])), Want: int64(len(cells[0]))})
+		}
+	}
+	// Return cells
+	return cells, nil
+}
+
+// boxColumns returns the cells of content lines c without vertical grid lines. Columns are separated by runs of at
+// least twice padding p of spaces in all lines. The cells are trimmed of spaces. It returns nil and an error,
+// if p is lower than one.
+func boxColumns(c []string, p int) ([][]string, error) {
+	// Return nil and an error, if columns cannot be separated
+	if p < 1 {
+		return nil, tserr.Higher(&tserr.HigherArgs{Var: "padding", Actual: int64(p), LowerBound: 1})
+	}
+	// Retrieve runes of each line and the maximum number of runes
+	r, n := make([][]rune, len(c)), 0
+	for i, s := range c {
+		r[i] = []rune(s)
+		n = max(n, len(r[i]))
+	}
+	// blank returns true, if all lines have a space or no rune at index j
+	blank := func(j int) bool {
+		for _, u := range r {
+			if (j < len(u)) && (u[j] != ' ') {
+				return false
+			}
+		}
+		return true
+	}
+	// Retrieve start and end index of each column. Runs of spaces shorter than 2*p are part of a column.
+	var cols [][2]int
+	for j, gap := 0, 2*p; j < n; j++ {
+		if blank(j) {
+			gap++
+			continue
+		}
+		if (gap >= 2*p) || (len(cols) == 0) {
+			cols = append(cols, [2]int{j, j + 1})
+		} else {
+			cols[len(cols)-1][1] = j + 1
+		}
+		gap = 0
+	}
+	// Retrieve cells of each line
+	cells := make([][]string, len(r))
+	for i, u := range r {
+		cells[i] = make([]string, len(cols))
+		for j, col := range cols {
+			if col[0] < len(u) {
+				cells[i][j] = strings.Trim(string(u[col[0]:min(col[1], len(u))]), " ")
+			}
+		}
+	}
+	// Return cells
+	return cells, nil
+}
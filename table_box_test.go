@@ -0,0 +1,125 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable_test
+
+// Import Go standard library packages as well as tstable and tserr
+import (
+	"os"            // os
+	"path/filepath" // filepath
+	"strings"       // strings
+	"testing"       // testing
+
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tstable" // tstable
+)
+
+// TestReadBox tests reading the golden files of all grids of AllGrids with the grid provided and with the grid
+// detected. The test fails if ReadBox returns an error or if the printed table does not equal the golden file.
+func TestReadBox(t *testing.T) {
+	// Iterate over all grids of AllGrids
+	for name, grid := range tstable.AllGrids {
+		// Retrieve contents of the golden file
+		b, e := os.ReadFile(filepath.Join("testdata", name+".golden"))
+		// The test fails if ReadFile returns an error
+		if e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ReadFile", Fn: name, Err: e}))
+		}
+		// Read the box representation with the grid provided and with the grid detected
+		for _, a := range []*tstable.ReadBoxArgs{{Grid: grid}, nil} {
+			tbl, e := tstable.ReadBox(strings.NewReader(string(b)), a)
+			// The test fails if ReadBox returns an error
+			if e != nil {
+				t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ReadBox", Fn: name, Err: e}))
+			}
+			// Sort the table like the test table
+			if e := tbl.SortBy(sortby); e != nil {
+				t.Error(tserr.Op(&tserr.OpArgs{Op: "SortBy", Fn: name, Err: e}))
+			}
+			// Evaluate the table
+			evalTable(name, tbl, t)
+		}
+	}
+}
+
+// testReadBox prints table tbl, reads the box representation with ReadBox and arguments a and fails the test, if
+// the printed tables do not match.
+func testReadBox(t *testing.T, name string, tbl *tstable.Table, a *tstable.ReadBoxArgs) {
+	// Retrieve the box representation of tbl
+	s, e := tbl.Print()
+	// The test fails if Print returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Print", Fn: name, Err: e}))
+	}
+	// Read the box representation
+	r, e := tstable.ReadBox(strings.NewReader(s), a)
+	// The test fails if ReadBox returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ReadBox", Fn: name, Err: e}))
+	}
+	// The test fails if the box representations do not match
+	p, e := r.Print()
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Print", Fn: name, Err: e}))
+	}
+	if p != s {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: name, Actual: p, Want: s}))
+	}
+}
+
+// TestReadBoxPadding tests reading tables without rows and tables with padding zero and a custom grid. The test fails
+// if the printed tables do not match.
+func TestReadBoxPadding(t *testing.T) {
+	// Retrieve a table without rows
+	tbl, e := tstable.New([]string{"Ring", "Bearer"})
+	// The test fails if New returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "New", Fn: "table", Err: e}))
+	}
+	// Test tables without rows with the simple grid and the empty grid
+	testReadBox(t, "NoRows", tbl, nil)
+	if e := tbl.SetGrid(&tstable.EmptyGrid); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "SetGrid", Fn: "EmptyGrid", Err: e}))
+	}
+	testReadBox(t, "NoRowsEmptyGrid", tbl, nil)
+	// Test a table with a custom grid, padding zero, spaces in cells and an empty cell
+	if e := tbl.AddRow([]string{"The One Ring", ""}); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: "table", Err: e}))
+	}
+	g := &tstable.Grid{Hi: '-', Hb: '=', Vi: '|', Vb: '!', Hvi: '+', Hvl: '+', Hvr: '+', Hvt: '=', Hvb: '=', Hvtl: '=', Hvbl: '=', Hvtr: '=', Hvbr: '='}
+	if e := tbl.SetGrid(g); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "SetGrid", Fn: "custom", Err: e}))
+	}
+	if e := tbl.SetPadding(0); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "SetPadding", Fn: "table", Err: e}))
+	}
+	testReadBox(t, "Custom", tbl, &tstable.ReadBoxArgs{Grid: g})
+}
+
+// TestReadBoxErr tests ReadBox with a nil reader, too few lines, a missing bottom line, a grid not matching, a row
+// with too many cells, an undetectable grid and the empty grid without padding. The test fails if ReadBox does not
+// return an error.
+func TestReadBoxErr(t *testing.T) {
+	// The test fails if ReadBox does not return an error for a nil reader
+	if _, e := tstable.ReadBox(nil, nil); e == nil {
+		t.Error(tserr.NilFailed("ReadBox"))
+	}
+	// Test cases with the box representation and the provided grid, nil for detecting the grid
+	for _, c := range []struct {
+		s string
+		g *tstable.Grid
+	}{
+		{"", nil},
+		{"┌───┐\n│ a │\n", nil},
+		{"┌───┐\n│ a │\n├───┤\n│ 1 │\n", nil},
+		{"┌───┐\n│ a │\n└───┘\n", &tstable.RoundGrid},
+		{"┌───┬───┐\n│ a │ b │\n├───┼───┤\n│ 1 │ 2 │ 3 │\n└───┴───┘\n", &tstable.SimpleGrid},
+		{"+---+\n| a |\n+---+\n", nil},
+		{"\na  b\n\n", &tstable.EmptyGrid},
+	} {
+		// The test fails if ReadBox does not return an error
+		if _, e := tstable.ReadBox(strings.NewReader(c.s), &tstable.ReadBoxArgs{Grid: c.g}); e == nil {
+			t.Error(tserr.NilFailed("ReadBox"))
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable
+
+// Import Go standard packages and tserr
+import (
+	"encoding/csv" // csv
+	"errors"       // errors
+	"io"           // io
+	"strconv"      // strconv
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// CSV writes table t in CSV format with the header as first record to w. The fields are separated by comma. The rows
+// are sorted in the same order as with Print. It returns an error, if writing to w fails.
+func (t *Table) CSV(w io.Writer, comma rune) error {
+	// Return an error if w is nil
+	if w == nil {
+		return tserr.NilPtr()
+	}
+	// Retrieve view of t
+	v, e := t.view()
+	// Return an error if view fails
+	if e != nil {
+		return tserr.Op(&tserr.OpArgs{Op: "view", Fn: "table", Err: e})
+	}
+	// Write CSV representation
+	return v.csv(w, comma)
+}
+
+// csv writes view v in CSV format with fields separated by comma to w.
+func (v *View) csv(w io.Writer, comma rune) error {
+	// Retrieve CSV writer
+	c := csv.NewWriter(w)
+	c.Comma = comma
+	// Write header
+	if e := c.Write(v.header); e != nil {
+		return tserr.Op(&tserr.OpArgs{Op: "Write", Fn: "header", Err: e})
+	}
+	// Write rows
+	if e := c.WriteAll(v.rows); e != nil {
+		return tserr.Op(&tserr.OpArgs{Op: "WriteAll", Fn: "rows", Err: e})
+	}
+	// Return nil
+	return nil
+}
+
+// ReadCSVArgs holds the arguments for reading a table in CSV format. Empty fields are set to default values.
+//
+//	Header:		header of the table, if nil the first record is the header
+//	Comma:		field delimiter (default ,)
+//	Comment:	lines beginning with the comment character are skipped (default none)
+//	LazyQuotes:	allow quotes in unquoted fields and non-doubled quotes in quoted fields
+//	Ragged:		handling of records with a number of fields different from the header (default RaggedError)
+//	NonPrintable:	handling of non-printable runes in fields (default NonPrintableError)
+type ReadCSVArgs struct {
+	Header       []string     // Header of the table
+	Comma        rune         // Field delimiter
+	Comment      rune         // Comment character
+	LazyQuotes   bool         // Relaxed quoting
+	Ragged       Ragged       // Policy for ragged records
+	NonPrintable NonPrintable // Policy for non-printable runes
+}
+
+// ReadCSV returns a new Table read from r in CSV format. The first record is the header of the table, unless
+// a header is provided in a. Records with a number of fields different from the header are handled according to the
+// Ragged policy and non-printable runes, for example newlines in quoted fields, according to the NonPrintable policy.
+// If a is nil, default values are used. It returns nil and an error, if r does not contain a header, if r is not
+// valid CSV or if a record cannot be added to the table.
+func ReadCSV(r io.Reader, a *ReadCSVArgs) (*Table, error) {
+	// Return nil and an error if r is nil
+	if r == nil {
+		return nil, tserr.NilPtr()
+	}
+	// Use default arguments, if a is nil
+	if a == nil {
+		a = &ReadCSVArgs{}
+	}
+	// Retrieve CSV reader allowing a variable number of fields per record
+	c := csv.NewReader(r)
+	c.FieldsPerRecord, c.LazyQuotes, c.Comment = -1, a.LazyQuotes, a.Comment
+	if a.Comma != 0 {
+		c.Comma = a.Comma
+	}
+	// Retrieve header from a or from the first record
+	h := a.Header
+	if h == nil {
+		var e error
+		h, e = c.Read()
+		// Return nil and an error, if r does not contain a record
+		if errors.Is(e, io.EOF) {
+			return nil, tserr.Empty("CSV")
+		}
+		// Return nil and an error, if the first record cannot be read
+		if e != nil {
+			return nil, tserr.Op(&tserr.OpArgs{Op: "Read", Fn: "CSV header", Err: e})
+		}
+	}
+	// Retrieve new table with header h
+	t, e := newImport(h, a.NonPrintable)
+	if e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "newImport", Fn: "CSV", Err: e})
+	}
+	// Read records until the end of r
+	for {
+		f, e := c.Read()
+		if errors.Is(e, io.EOF) {
+			break
+		}
+		// Return nil and an error, if the record cannot be read
+		if e != nil {
+			return nil, tserr.Op(&tserr.OpArgs{Op: "Read", Fn: "CSV", Err: e})
+		}
+		// Fit the record to the header
+		f, e = fit(f, len(h), a.Ragged)
+		if e != nil {
+			line, _ := c.FieldPos(0)
+			return nil, tserr.Op(&tserr.OpArgs{Op: "fit", Fn: "CSV line " + strconv.Itoa(line), Err: e})
+		}
+		// Add the record to the table
+		if e := t.addImport(f, a.NonPrintable); e != nil {
+			line, _ := c.FieldPos(0)
+			return nil, tserr.Op(&tserr.OpArgs{Op: "addImport", Fn: "CSV line " + strconv.Itoa(line), Err: e})
+		}
+	}
+	// Return table
+	return t, nil
+}
+
+// ReadTSV returns a new Table read from r in TSV format. It equals ReadCSV with a tab as field delimiter, if no
+// other delimiter is set in a.
+func ReadTSV(r io.Reader, a *ReadCSVArgs) (*Table, error) {
+	// Copy arguments to leave a unchanged
+	b := ReadCSVArgs{}
+	if a != nil {
+		b = *a
+	}
+	// Set tab as field delimiter
+	if b.Comma == 0 {
+		b.Comma = '\t'
+	}
+	// Return table read with ReadCSV
+	return ReadCSV(r, &b)
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable_test
+
+// Import Go standard library packages as well as tstable and tserr
+import (
+	"bytes"   // bytes
+	"fmt"     // fmt
+	"slices"  // slices
+	"strings" // strings
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tstable" // tstable
+)
+
+// TestCSV tests the CSV and TSV representation of a table with a quoted cell. The test fails if CSV
+// returns an error or if the representation does not match.
+func TestCSV(t *testing.T) {
+	// Retrieve new test table
+	tbl, e := tstable.New([]string{"Name", "Note"})
+	// The test fails if New returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "New", Fn: "table", Err: e}))
+	}
+	// Add a row with a comma and a quote
+	if e := tbl.AddRow([]string{"Frodo", `Ring, "precious"`}); e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: "table", Err: e}))
+	}
+	// Test comma and tab as field separator
+	for comma, want := range map[rune]string{
+		',':  "Name,Note\nFrodo,\"Ring, \"\"precious\"\"\"\n",
+		'\t': "Name\tNote\nFrodo\t\"Ring, \"\"precious\"\"\"\n",
+	} {
+		var b bytes.Buffer
+		// The test fails if CSV returns an error
+		if e := tbl.CSV(&b, comma); e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "CSV", Fn: "table", Err: e}))
+		}
+		// The test fails if the representation does not match
+		if b.String() != want {
+			t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "CSV", Actual: b.String(), Want: want}))
+		}
+	}
+}
+
+// TestCSVErr tests CSV with a nil writer and an invalid separator. The test fails if CSV does not return an error.
+func TestCSVErr(t *testing.T) {
+	// The test fails if CSV does not return an error for a nil writer
+	if e := testTable(t).CSV(nil, ','); e == nil {
+		t.Error(tserr.NilFailed("CSV"))
+	}
+	// The test fails if CSV does not return an error for an invalid separator
+	if e := testTable(t).CSV(&bytes.Buffer{}, '"'); e == nil {
+		t.Error(tserr.NilFailed("CSV"))
+	}
+}
+
+// testReadCSV returns the header and rows of the table read from s with ReadCSV and arguments a. The test fails
+// if ReadCSV returns an error.
+func testReadCSV(t *testing.T, s string, a *tstable.ReadCSVArgs) [][]string {
+	// Read table from s
+	tbl, e := tstable.ReadCSV(strings.NewReader(s), a)
+	// The test fails if ReadCSV returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ReadCSV", Fn: "table", Err: e}))
+	}
+	// Retrieve header and rows as CSV records
+	var b bytes.Buffer
+	if e := tbl.CSV(&b, ';'); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "CSV", Fn: "table", Err: e}))
+	}
+	r := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	rows := make([][]string, len(r))
+	for i, l := range r {
+		rows[i] = strings.Split(l, ";")
+	}
+	// Return header and rows
+	return rows
+}
+
+// evalRows fails the test, if the header and rows in a do not equal want.
+func evalRows(t *testing.T, name string, a, want [][]string) {
+	if !slices.EqualFunc(a, want, slices.Equal[[]string]) {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: name, Actual: fmt.Sprint(a), Want: fmt.Sprint(want)}))
+	}
+}
+
+// TestReadCSV tests reading a table in CSV format with a header from the first record, a comment line and
+// a quoted field. The test fails if the header or rows do not match.
+func TestReadCSV(t *testing.T) {
+	s := "# members\nName,Weapon\nFrodo,\"Sting, Dagger\"\nSam,Pan\n"
+	a := testReadCSV(t, s, &tstable.ReadCSVArgs{Comment: '#'})
+	evalRows(t, "ReadCSV", a, [][]string{{"Name", "Weapon"}, {"Frodo", "Sting, Dagger"}, {"Sam", "Pan"}})
+}
+
+// TestReadTSV tests reading a table in TSV format with a provided header and lazy quotes. The test fails if the header
+// or rows do not match.
+func TestReadTSV(t *testing.T) {
+	tbl, e := tstable.ReadTSV(strings.NewReader("Frodo\t5'6\"\nSam\t5'5\"\n"), &tstable.ReadCSVArgs{Header: []string{"Name", "Height"}, LazyQuotes: true})
+	// The test fails if ReadTSV returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ReadTSV", Fn: "table", Err: e}))
+	}
+	// The test fails if the table does not match
+	s, e := tbl.Markdown()
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Markdown", Fn: "table", Err: e}))
+	}
+	want := "| Name | Height |\n| --- | --- |\n| Frodo | 5'6\" |\n| Sam | 5'5\" |\n"
+	if s != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "ReadTSV", Actual: s, Want: want}))
+	}
+}
+
+// TestReadCSVRagged tests the Ragged policies. The test fails if the header or rows do not match.
+func TestReadCSVRagged(t *testing.T) {
+	s := "a,b\n1\n2,3,4\n"
+	// Pad short rows and truncate long rows
+	evalRows(t, "RaggedFit", testReadCSV(t, s, &tstable.ReadCSVArgs{Ragged: tstable.RaggedFit}), [][]string{{"a", "b"}, {"1", ""}, {"2", "3"}})
+	// The test fails if ReadCSV does not return an error for a long row, if only padding is allowed
+	if _, e := tstable.ReadCSV(strings.NewReader(s), &tstable.ReadCSVArgs{Ragged: tstable.RaggedPad}); e == nil {
+		t.Error(tserr.NilFailed("ReadCSV"))
+	}
+	// The test fails if ReadCSV does not return an error for a short row, if only truncating is allowed
+	if _, e := tstable.ReadCSV(strings.NewReader(s), &tstable.ReadCSVArgs{Ragged: tstable.RaggedTruncate}); e == nil {
+		t.Error(tserr.NilFailed("ReadCSV"))
+	}
+	// The test fails if ReadCSV does not return an error for ragged rows per default
+	if _, e := tstable.ReadCSV(strings.NewReader(s), nil); e == nil {
+		t.Error(tserr.NilFailed("ReadCSV"))
+	}
+}
+
+// TestReadCSVNonPrintable tests the NonPrintable policies with a newline in a quoted field. The test fails if the
+// header or rows do not match.
+func TestReadCSVNonPrintable(t *testing.T) {
+	s := "a,b\n\"x\ny\",1\nz,2\n"
+	// Drop non-printable runes
+	evalRows(t, "NonPrintableDrop", testReadCSV(t, s, &tstable.ReadCSVArgs{NonPrintable: tstable.NonPrintableDrop}), [][]string{{"a", "b"}, {"xy", "1"}, {"z", "2"}})
+	// Replace non-printable runes
+	evalRows(t, "NonPrintableReplace", testReadCSV(t, s, &tstable.ReadCSVArgs{NonPrintable: tstable.NonPrintableReplace}), [][]string{{"a", "b"}, {"x y", "1"}, {"z", "2"}})
+	// Skip rows with non-printable runes
+	evalRows(t, "NonPrintableSkip", testReadCSV(t, s, &tstable.ReadCSVArgs{NonPrintable: tstable.NonPrintableSkip}), [][]string{{"a", "b"}, {"z", "2"}})
+	// The test fails if ReadCSV does not return an error per default
+	if _, e := tstable.ReadCSV(strings.NewReader(s), nil); e == nil {
+		t.Error(tserr.NilFailed("ReadCSV"))
+	}
+}
+
+// TestReadCSVErr tests ReadCSV with a nil reader, an empty reader and invalid CSV. The test fails if ReadCSV
+// does not return an error.
+func TestReadCSVErr(t *testing.T) {
+	for _, r := range []*strings.Reader{nil, strings.NewReader(""), strings.NewReader("a,b\n\"1,2\n")} {
+		var e error
+		if r == nil {
+			_, e = tstable.ReadCSV(nil, nil)
+		} else {
+			_, e = tstable.ReadCSV(r, nil)
+		}
+		if e == nil {
+			t.Error(tserr.NilFailed("ReadCSV"))
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable
+
+// Import Go standard packages and tserr
+import (
+	"bufio"   // bufio
+	"io"      // io
+	"strconv" // strconv
+	"strings" // strings
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// ReadFixedWidthArgs holds the arguments for reading a table from fixed-width text. Empty fields are set to default values.
+//
+//	Gap:		minimum number of spaces separating columns in the header line (default 1)
+//	NonPrintable:	handling of non-printable runes in cells (default NonPrintableError)
+type ReadFixedWidthArgs struct {
+	Gap          int          // Minimum number of spaces between header columns
+	NonPrintable NonPrintable // Policy for non-printable runes
+}
+
+// ReadFixedWidth returns a new Table read from r in fixed-width text, like the output of ps, df or docker ps. The first
+// non-empty line is the header. Columns of the header are separated by at least Gap spaces, so that header columns
+// containing single spaces, like CONTAINER ID of docker ps, require a Gap of 2. The column boundaries are inferred
+// from the header: a column ends at the last position between two header columns, which is a space in all lines. This
+// keeps right-aligned cells, which extend left of their header, in their column. The last column extends to the end
+// of each line and may contain spaces. Cells are trimmed of spaces and empty lines are skipped. A column is set
+// right-aligned, if all its cells end with the end of its header and not all start with its start. If a is nil,
+// default values are used. It returns nil and an error, if Gap is negative, if r does not contain a header or if
+// a row cannot be added to the table.
+func ReadFixedWidth(r io.Reader, a *ReadFixedWidthArgs) (*Table, error) {
+	// Return nil and an error if r is nil
+	if r == nil {
+		return nil, tserr.NilPtr()
+	}
+	// Use default arguments, if a is nil
+	if a == nil {
+		a = &ReadFixedWidthArgs{}
+	}
+	// Return nil and an error, if Gap is negative
+	if a.Gap < 0 {
+		return nil, tserr.Higher(&tserr.HigherArgs{Var: "gap", Actual: int64(a.Gap), LowerBound: 0})
+	}
+	gap := max(a.Gap, 1)
+	// Read non-empty lines and their line numbers
+	var (
+		l   [][]rune
+		num []int
+	)
+	s := bufio.NewScanner(r)
+	for i := 1; s.Scan(); i++ {
+		if strings.TrimSpace(s.Text()) != "" {
+			l, num = append(l, []rune(strings.TrimRight(s.Text(), " \r"))), append(num, i)
+		}
+	}
+	// Return nil and an error, if r cannot be read
+	if e := s.Err(); e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "Scan", Fn: "fixed-width text", Err: e})
+	}
+	// Return nil and an error, if r does not contain a header
+	if len(l) == 0 {
+		return nil, tserr.Empty("fixed-width text")
+	}
+	// Retrieve columns of the header and the start of each column
+	var (
+		cols  [][2]int
+		start []int
+	)
+	for _, c := range fixedColumns(l[0], gap) {
+		// The first column starts at the beginning of the line
+		if len(cols) == 0 {
+			cols, start = append(cols, c), append(start, 0)
+			continue
+		}
+		// The column starts after the last position between the header columns, which is a space in all lines
+		p := c[0] - 1
+		for (p >= cols[len(cols)-1][1]) && !fixedBlank(l[1:], p) {
+			p--
+		}
+		// Merge the column with the previous column, if cells cross all positions between the header columns
+		if p < cols[len(cols)-1][1] {
+			cols[len(cols)-1][1] = c[1]
+			continue
+		}
+		cols, start = append(cols, c), append(start, p+1)
+	}
+	// Retrieve cells of each line. A column is right-aligned, if all cells end with the end of the header and
+	// not all cells start with the start of the header.
+	cells, ends, starts := make([][]string, len(l)), make([]bool, len(cols)), make([]bool, len(cols))
+	for j := range cols {
+		ends[j], starts[j] = true, true
+	}
+	for i, u := range l {
+		cells[i] = make([]string, len(cols))
+		for j := range cols {
+			// Retrieve cell j from the start of column j to the start of the next column
+			end := len(u)
+			if j+1 < len(cols) {
+				end = min(end, start[j+1])
+			}
+			if start[j] >= end {
+				continue
+			}
+			c := string(u[start[j]:end])
+			cells[i][j] = strings.Trim(c, " ")
+			// Skip the header and empty cells for the alignment
+			if (i == 0) || (cells[i][j] == "") {
+				continue
+			}
+			// Evaluate whether the cell ends with the end and starts with the start of the header
+			ends[j] = ends[j] && (start[j]+len([]rune(strings.TrimRight(c, " "))) == cols[j][1])
+			starts[j] = starts[j] && (start[j]+len([]rune(c))-len([]rune(strings.TrimLeft(c, " "))) == cols[j][0])
+		}
+	}
+	// Retrieve new table with the header
+	t, e := newImport(cells[0], a.NonPrintable)
+	if e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "newImport", Fn: "fixed-width line " + strconv.Itoa(num[0]), Err: e})
+	}
+	// Set right-aligned columns
+	for j := range cols {
+		if ends[j] && !starts[j] {
+			t.align[j] = AlignRight
+		}
+	}
+	// Add rows
+	for i, c := range cells[1:] {
+		if e := t.addImport(c, a.NonPrintable); e != nil {
+			return nil, tserr.Op(&tserr.OpArgs{Op: "addImport", Fn: "fixed-width line " + strconv.Itoa(num[i+1]), Err: e})
+		}
+	}
+	// Return table
+	return t, nil
+}
+
+// fixedColumns returns the start and end rune index of each column of header line h. Columns are separated by at
+// least gap spaces.
+func fixedColumns(h []rune, gap int) [][2]int {
+	var cols [][2]int
+	// Number of spaces since the last rune of the previous column
+	n := gap
+	for j, u := range h {
+		if u == ' ' {
+			n++
+			continue
+		}
+		// Start a new column after gap spaces, otherwise extend the current column
+		if n >= gap {
+			cols = append(cols, [2]int{j, j + 1})
+		} else {
+			cols[len(cols)-1][1] = j + 1
+		}
+		n = 0
+	}
+	// Return columns
+	return cols
+}
+
+// fixedBlank returns true, if all lines l have a space or no rune at index p.
+func fixedBlank(l [][]rune, p int) bool {
+	for _, u := range l {
+		if (p < len(u)) && (u[p] != ' ') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable_test
+
+// Import Go standard library packages as well as tstable and tserr
+import (
+	"strings" // strings
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tstable" // tstable
+)
+
+// testReadFixedWidth reads s with ReadFixedWidth and arguments a and fails the test, if the Markdown representation
+// of the table does not equal want.
+func testReadFixedWidth(t *testing.T, s string, a *tstable.ReadFixedWidthArgs, want string) {
+	// Read table from s
+	tbl, e := tstable.ReadFixedWidth(strings.NewReader(s), a)
+	// The test fails if ReadFixedWidth returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ReadFixedWidth", Fn: "table", Err: e}))
+	}
+	// Retrieve Markdown representation
+	m, e := tbl.Markdown()
+	// The test fails if Markdown returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Markdown", Fn: "table", Err: e}))
+	}
+	// The test fails if the Markdown representation does not match
+	if m != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "ReadFixedWidth", Actual: m, Want: want}))
+	}
+}
+
+// TestReadFixedWidthPs tests reading the output of ps with right-aligned columns extending left of their header and
+// a last column containing spaces. The test fails if the table does not match.
+func TestReadFixedWidthPs(t *testing.T) {
+	s := "    PID TTY          TIME CMD\n" +
+		"      1 ?        00:00:01 /sbin/init splash\n" +
+		"  12345 pts/0    00:00:00 bash\n\n" +
+		"    731 ?        01:12:07 containerd --log-level info\n"
+	want := "| PID | TTY | TIME | CMD |\n| ---: | --- | ---: | --- |\n" +
+		"| 1 | ? | 00:00:01 | /sbin/init splash |\n" +
+		"| 12345 | pts/0 | 00:00:00 | bash |\n" +
+		"| 731 | ? | 01:12:07 | containerd --log-level info |\n"
+	testReadFixedWidth(t, s, nil, want)
+}
+
+// TestReadFixedWidthDocker tests reading the output of docker ps with a header column containing a space, cells
+// containing spaces and an empty cell. The test fails if the table does not match.
+func TestReadFixedWidthDocker(t *testing.T) {
+	s := "CONTAINER ID   IMAGE          STATUS         NAMES\n" +
+		"4c01db0b339c   ubuntu:22.04   Up 2 hours     shire\n" +
+		"d7886598dbe2   nginx          Exited (0)     \n"
+	want := "| CONTAINER ID | IMAGE | STATUS | NAMES |\n| --- | --- | --- | --- |\n" +
+		"| 4c01db0b339c | ubuntu:22.04 | Up 2 hours | shire |\n" +
+		"| d7886598dbe2 | nginx | Exited (0) |  |\n"
+	testReadFixedWidth(t, s, nil, want)
+}
+
+// TestReadFixedWidthDf tests reading the output of df with right-aligned sizes. The test fails if the table does not match.
+func TestReadFixedWidthDf(t *testing.T) {
+	s := "Filesystem      Size  Used Avail Use% Mounted on\n" +
+		"/dev/sda1       457G  213G  221G  50% /\n" +
+		"tmpfs           7.8G   12M  7.8G   1% /dev/shm\n"
+	want := "| Filesystem | Size | Used | Avail | Use% | Mounted on |\n| --- | --- | ---: | ---: | ---: | --- |\n" +
+		"| /dev/sda1 | 457G | 213G | 221G | 50% | / |\n" +
+		"| tmpfs | 7.8G | 12M | 7.8G | 1% | /dev/shm |\n"
+	testReadFixedWidth(t, s, nil, want)
+}
+
+// TestReadFixedWidthGap tests reading a header with single spaces in a column name and short cells with a gap of 2.
+// The test fails if the table does not match.
+func TestReadFixedWidthGap(t *testing.T) {
+	s := "NAME    MOUNTED ON\nshire   /\n"
+	want := "| NAME | MOUNTED ON |\n| --- | --- |\n| shire | / |\n"
+	testReadFixedWidth(t, s, &tstable.ReadFixedWidthArgs{Gap: 2}, want)
+}
+
+// TestReadFixedWidthErr tests ReadFixedWidth with a nil reader, empty text, a negative gap and a non-printable rune.
+// The test fails if ReadFixedWidth does not return an error.
+func TestReadFixedWidthErr(t *testing.T) {
+	// The test fails if ReadFixedWidth does not return an error for a nil reader
+	if _, e := tstable.ReadFixedWidth(nil, nil); e == nil {
+		t.Error(tserr.NilFailed("ReadFixedWidth"))
+	}
+	// Test cases with the text and the arguments
+	for _, c := range []struct {
+		s string
+		a *tstable.ReadFixedWidthArgs
+	}{
+		{"\n  \n", nil},
+		{"A B\n1 2\n", &tstable.ReadFixedWidthArgs{Gap: -1}},
+		{"A B\n1 \a\n", nil},
+	} {
+		// The test fails if ReadFixedWidth does not return an error
+		if _, e := tstable.ReadFixedWidth(strings.NewReader(c.s), c.a); e == nil {
+			t.Error(tserr.NilFailed("ReadFixedWidth"))
+		}
+	}
+}
@@ -3,217 +3,316 @@
 // that can be found in the LICENSE file.
 package tstable
 
-// Import Go standard library package strings as well as tsfio and tserr
+// Import Go standard library packages as well as tsfio and tserr
 import (
-	"strings" // strings
+	"io"           // io
+	"strings"      // strings
+	"unicode"      // unicode
+	"unicode/utf8" // utf8
 
 	"github.com/thorstenrie/tserr" // tserr
 	"github.com/thorstenrie/tsfio" // tsfio
 )
 
-// spaces returns the spaces as string for padding. It returns an empty string and an error, if any.
-func (t *Table) spaces() (string, error) {
-	// Return an empty string and an error if t is nil
-	if t == nil {
-		return "", tserr.NilPtr()
+// boxChunk is the number of bytes buffered before a chunk of the box representation is written to an io.Writer
+const boxChunk int = 64 * 1024
+
+// A box holds the grid segments of the box representation of a view. The segments are computed once per
+// render, so that rendering a row only writes the precomputed segments and the padded cells.
+type box struct {
+	v      *View    // View to be rendered
+	top    string   // Top horizontal grid line
+	mid    string   // Horizontal grid line below the header
+	bottom string   // Bottom horizontal grid line
+	left   []string // Vertical grid line with padding left of each column
+	right  string   // Vertical grid line with padding at the end of each line
+	blank  string   // Spaces to pad the widest column
+}
+
+// newBox returns the box of view v with precomputed grid segments. It returns nil and an error, if the padding
+// or a column width is negative.
+func (v *View) newBox() (*box, error) {
+	// Return nil and an error if padding is negative
+	if v.padding < 0 {
+		return nil, tserr.Higher(&tserr.HigherArgs{Var: "padding", Actual: int64(v.padding), LowerBound: 0})
+	}
+	// Retrieve spaces for padding
+	spaces := strings.Repeat(" ", v.padding)
+	// Retrieve the maximum column width
+	wmax := 0
+	for _, w := range v.width {
+		// Return nil and an error if width is negative
+		if w < 0 {
+			return nil, tserr.Higher(&tserr.HigherArgs{Var: "width", Actual: int64(w), LowerBound: 0})
+		}
+		wmax = max(wmax, w)
+	}
+	// Retrieve vertical grid lines
+	cmax := len(v.width)
+	x := &box{v: v, left: make([]string, cmax), blank: strings.Repeat(" ", wmax)}
+	for c := range x.left {
+		x.left[c] = spaces + v.vline(c) + spaces
 	}
-	// Return an empty string and an error if padding is negative
-	if t.padding < 0 {
-		return "", tserr.Higher(&tserr.HigherArgs{Var: "padding", Actual: int64(t.padding), LowerBound: 0})
+	x.right = spaces + v.vline(cmax) + "\n"
+	// Retrieve horizontal grid lines
+	x.top, x.mid, x.bottom = v.hline(boxTop), v.hline(boxMid), v.hline(boxBottom)
+	// The horizontal grid line below the header is the bottom line, if the view does not have rows
+	if len(v.rows) == 0 {
+		x.mid = x.bottom
 	}
-	// Return spaces for padding
-	return strings.Repeat(" ", t.padding), nil
+	// Return box
+	return x, nil
 }
 
-// hline returns a horizontal grid line (on top) of row with index r for table t as a string. It returns
-// an empty string and an error, if any.
-func (t *Table) hline(r int) (string, error) {
-	// Initialize return value as empty string
-	text := ""
-	// Return an empty string and an error if t is nil
-	if t == nil {
-		return text, tserr.NilPtr()
+// size returns the estimated number of bytes of the box representation. It is exact, if all cells
+// only contain single-byte runes.
+func (x *box) size() int {
+	// Number of bytes of a line without cells
+	l := len(x.right)
+	for _, s := range x.left {
+		l += len(s)
 	}
-	// Return an empty string and an error if width is nil
-	if t.width == nil {
-		return text, tserr.NilPtr()
+	for _, w := range x.v.width {
+		l += w
 	}
-	// Return an empty string and an error if rows is nil
-	if t.rows == nil {
-		return "", tserr.NilPtr()
+	// Number of bytes of the header, the rows and the horizontal grid lines
+	n := (len(x.v.rows)+1)*l + len(x.top) + len(x.mid)
+	if len(x.v.rows) > 0 {
+		n += len(x.bottom)
 	}
-	// Add initial padding to the horizontal line
-	spaces, e := t.spaces()
-	if e != nil {
-		return "", tserr.Op(&tserr.OpArgs{Op: "spaces", Fn: "table", Err: e})
+	// Return the estimated number of bytes
+	return n
+}
+
+// row writes row r padded to the column widths with the vertical grid lines to b. It returns an error, if the size of r
+// does not equal the number of columns or if a cell is wider than its column.
+func (x *box) row(b *strings.Builder, r []string) error {
+	// Return an error, if the size of row r is not equal to the size of width
+	if len(x.v.width) != len(r) {
+		return tserr.Higher(&tserr.HigherArgs{Var: "size of row", Actual: int64(len(r)), LowerBound: int64(len(x.v.width))})
 	}
-	text += spaces
-	// Iterate width column by column
-	for i, w := range t.width {
-		// Return an empty string and an error if width is negative
-		if w < 0 {
-			return "", tserr.Higher(&tserr.HigherArgs{Var: "width", Actual: int64(w), LowerBound: 0})
+	// Write cells of row r
+	for j, c := range r {
+		// Number of spaces to fill c up to the width of column j
+		n := x.v.width[j] - utf8.RuneCountInString(c)
+		// Return an error, if c is wider than column j
+		if n < 0 {
+			return tserr.Higher(&tserr.HigherArgs{Var: "width", Actual: int64(x.v.width[j]), LowerBound: int64(utf8.RuneCountInString(c))})
 		}
-		// Retrieve the horizontal vertical rune
-		hv, e := t.hv_rune(r, i)
-		// Return an empty string and an error if hv_rune fails
-		if e != nil {
-			return "", tserr.Op(&tserr.OpArgs{Op: "hv_rune", Fn: "table", Err: e})
+		// Write vertical grid line and cell c padded according to the column alignment
+		b.WriteString(x.left[j])
+		switch x.v.Alignment(j) {
+		case AlignRight:
+			b.WriteString(x.blank[:n])
+			b.WriteString(c)
+		case AlignCenter:
+			b.WriteString(x.blank[:n/2])
+			b.WriteString(c)
+			b.WriteString(x.blank[:n-n/2])
+		default:
+			b.WriteString(c)
+			b.WriteString(x.blank[:n])
 		}
-		// Retrieve the horizontal line rune
-		h, e := t.h_rune(r)
-		// Return an empty string and an error if h_rune fails
-		if e != nil {
-			return "", tserr.Op(&tserr.OpArgs{Op: "h_rune", Fn: "table", Err: e})
-		}
-		// Add a horizontal line runes for each column of the table
-		text += hv + strings.Repeat(h, t.padding+t.padding+w)
-	}
-	// Retrieve the horizontal vertical rune at the end of the horizontal line
-	hv, e := t.hv_rune(r, len(t.header))
-	// Return an empty string and an error if hv_rune fails
-	if e != nil {
-		return "", tserr.Op(&tserr.OpArgs{Op: "hv_rune", Fn: "table", Err: e})
 	}
-	// End rune of the horizontal line
-	text += hv + "\n"
-	// Return the horizontal grid line and nil
-	return text, nil
-}
-
-// h_rune returns the horizontal grid line rune for row r. The returned rune depends on whether
-// it is the top line with r = 0, the bottom line with r = rmax or a line in between. It returns an
-// empty string and an error if r is negative or r is higher than rmax.
-func (t *Table) h_rune(r int) (string, error) {
-	// Return an empty string and an error if t is nil
-	if t == nil {
-		return "", tserr.NilPtr()
-	}
-	// Return an empty string and an error if r is negative
-	if r < 0 {
-		return "", tserr.Higher(&tserr.HigherArgs{Var: "row index", Actual: int64(r), LowerBound: 0})
-	}
-	// Return an empty string and an error if rows is nil
-	if t.rows == nil {
-		return "", tserr.NilPtr()
-	}
-	// Maximum number of horizontal grid lines
-	rmax := len(t.rows) + 1
-	// Return an empty string and an error if r is higher tham rmax
-	if r > rmax {
-		return "", tserr.Lower(&tserr.LowerArgs{Var: "row index", Actual: int64(r), HigherBound: int64(rmax)})
-	}
-	// Return the horizontal border grid line rune for the top line or the bottom line
-	if (r == 0) || (r == rmax) {
-		return tsfio.RuneToPrintable(t.grid.Hb), nil
-	}
-	// Return the horizontal grid line rune
-	return tsfio.RuneToPrintable(t.grid.Hi), nil
+	// Write vertical grid line at the end of the line
+	b.WriteString(x.right)
+	// Return nil
+	return nil
 }
 
-// hv_rune returns the horizontal vertical grid line for row r and column c. It returns an
-// an empty string and an error if r or c are negative as well as if r or c are higher than rmax or cmax.
-func (t *Table) hv_rune(r, c int) (string, error) {
-	// Return an empty string and an error if t is nil
-	if t == nil {
-		return "", tserr.NilPtr()
+// box returns view v as a box drawn with the grid of v. It returns an empty string and an error, if any.
+func (v *View) box() (string, error) {
+	// Retrieve box with precomputed grid segments
+	x, e := v.newBox()
+	// Return an empty string and an error, if newBox fails
+	if e != nil {
+		return "", tserr.Op(&tserr.OpArgs{Op: "newBox", Fn: "view", Err: e})
 	}
-	// Return an empty string and an error if r is negative
-	if r < 0 {
-		return "", tserr.Higher(&tserr.HigherArgs{Var: "row index", Actual: int64(r), LowerBound: 0})
+	// Allocate the builder with the estimated size of the box representation
+	var b strings.Builder
+	b.Grow(x.size())
+	// Write top horizontal grid line, header and horizontal grid line below the header
+	b.WriteString(x.top)
+	if e := x.row(&b, v.header); e != nil {
+		return "", tserr.Op(&tserr.OpArgs{Op: "row", Fn: "header", Err: e})
 	}
-	// Return an empty string and an error fi c is negative
-	if c < 0 {
-		return "", tserr.Higher(&tserr.HigherArgs{Var: "column index", Actual: int64(c), LowerBound: 0})
+	b.WriteString(x.mid)
+	// Return box representation if the view does not have rows
+	if len(v.rows) == 0 {
+		return b.String(), nil
 	}
-	// Return an empty string and an error if rows is nil
-	if t.rows == nil {
-		return "", tserr.NilPtr()
+	// Write rows
+	for _, r := range v.rows {
+		if e := x.row(&b, r); e != nil {
+			return "", tserr.Op(&tserr.OpArgs{Op: "row", Fn: "rows", Err: e})
+		}
 	}
-	// Return an empty string and an error if header is nil
-	if t.header == nil {
-		return "", tserr.NilPtr()
+	// Write bottom horizontal grid line and return box representation
+	b.WriteString(x.bottom)
+	return b.String(), nil
+}
+
+// boxTo writes view v as a box drawn with the grid of v to w. The box representation is written in chunks.
+// It returns the number of bytes written and an error, if any.
+func (v *View) boxTo(w io.Writer) (int64, error) {
+	// Retrieve box with precomputed grid segments
+	x, e := v.newBox()
+	// Return zero and an error, if newBox fails
+	if e != nil {
+		return 0, tserr.Op(&tserr.OpArgs{Op: "newBox", Fn: "view", Err: e})
 	}
-	// Maximum number of horizontal grid lines
-	rmax := len(t.rows) + 1
-	// Maximum number of vertical grid lines
-	cmax := len(t.header)
-	// Return an empty string and an error if r is higher than rmax
-	if r > rmax {
-		return "", tserr.Lower(&tserr.LowerArgs{Var: "row index", Actual: int64(r), HigherBound: int64(rmax)})
-	}
-	// Return an empty string and an error if c is higher than cmax
-	if c > cmax {
-		return "", tserr.Lower(&tserr.LowerArgs{Var: "column index", Actual: int64(c), HigherBound: int64(cmax)})
-	}
-	// First horizontal grid line
-	if r == 0 {
-		// First column
-		if c == 0 {
-			return tsfio.RuneToPrintable(t.grid.Hvtl), nil
-		}
-		// Last column
-		if c == cmax {
-			return tsfio.RuneToPrintable(t.grid.Hvtr), nil
+	var (
+		n int64           // Number of bytes written
+		b strings.Builder // Chunk of the box representation
+	)
+	// Size of a chunk
+	chunk := min(boxChunk+len(x.top)+len(x.mid), x.size())
+	// flush writes the chunk to w and starts a new chunk
+	flush := func() error {
+		m, e := io.WriteString(w, b.String())
+		n += int64(m)
+		b = strings.Builder{}
+		if e != nil {
+			return tserr.Op(&tserr.OpArgs{Op: "WriteString", Fn: "view", Err: e})
 		}
-		// Any other column
-		return tsfio.RuneToPrintable(t.grid.Hvt), nil
-	}
-	// Last horizontal grid line
-	if r == rmax {
-		// First column
-		if c == 0 {
-			return tsfio.RuneToPrintable(t.grid.Hvbl), nil
+		return nil
+	}
+	b.Grow(chunk)
+	// Write top horizontal grid line, header and horizontal grid line below the header
+	b.WriteString(x.top)
+	if e := x.row(&b, v.header); e != nil {
+		return n, tserr.Op(&tserr.OpArgs{Op: "row", Fn: "header", Err: e})
+	}
+	b.WriteString(x.mid)
+	// Write rows and flush full chunks
+	for _, r := range v.rows {
+		if e := x.row(&b, r); e != nil {
+			return n, tserr.Op(&tserr.OpArgs{Op: "row", Fn: "rows", Err: e})
 		}
-		// Last column
-		if c == cmax {
-			return tsfio.RuneToPrintable(t.grid.Hvbr), nil
+		if b.Len() >= boxChunk {
+			if e := flush(); e != nil {
+				return n, e
+			}
+			b.Grow(chunk)
 		}
-		// Any other column
-		return tsfio.RuneToPrintable(t.grid.Hvb), nil
 	}
-	// First column of any other horizontal line
-	if c == 0 {
-		return tsfio.RuneToPrintable(t.grid.Hvl), nil
+	// Write bottom horizontal grid line, if the view has rows
+	if len(v.rows) > 0 {
+		b.WriteString(x.bottom)
+	}
+	// Write the last chunk
+	e = flush()
+	return n, e
+}
+
+// Horizontal grid lines of a box
+const (
+	boxTop    int = iota // Top horizontal grid line
+	boxMid               // Horizontal grid line below the header
+	boxBottom            // Bottom horizontal grid line
+)
+
+// hline returns the horizontal grid line l of view v, which is one of boxTop, boxMid or boxBottom, as a string.
+func (v *View) hline(l int) string {
+	var b strings.Builder
+	// Retrieve horizontal line rune. The top line and the bottom line are border lines.
+	h := tsfio.RuneToPrintable(v.grid.Hi)
+	if l != boxMid {
+		h = tsfio.RuneToPrintable(v.grid.Hb)
 	}
-	// Last grid line of any other horizontal line
-	if c == cmax {
-		return tsfio.RuneToPrintable(t.grid.Hvr), nil
+	// Add initial padding to the horizontal line
+	b.WriteString(strings.Repeat(" ", v.padding))
+	// Add the horizontal vertical rune and the horizontal line runes for each column of the table
+	for c, w := range v.width {
+		b.WriteString(v.hvRune(l, c))
+		b.WriteString(strings.Repeat(h, v.padding+v.padding+w))
 	}
-	// Any other horizontal vertical grid line
-	return tsfio.RuneToPrintable(t.grid.Hvi), nil
+	// End rune of the horizontal line
+	b.WriteString(v.hvRune(l, len(v.width)) + "\n")
+	// Return the horizontal grid line
+	return b.String()
 }
 
-// vline returns a vertical grid line for table t as a string. It returns an empty string and an error, if any.
-func (t *Table) vline(c int) (string, error) {
-	// Return an empty string and an error if t is nil
-	if t == nil {
-		return "", tserr.NilPtr()
+// hvRune returns the horizontal vertical grid line rune of horizontal grid line l and column c of view v as a string.
+// Column c equal to the number of columns is the end of the line.
+func (v *View) hvRune(l, c int) string {
+	// Maximum number of vertical grid lines
+	cmax := len(v.width)
+	// Retrieve grid runes for the first column, the last column and any other column of line l
+	first, last, inner := v.grid.Hvl, v.grid.Hvr, v.grid.Hvi
+	switch l {
+	case boxTop:
+		first, last, inner = v.grid.Hvtl, v.grid.Hvtr, v.grid.Hvt
+	case boxBottom:
+		first, last, inner = v.grid.Hvbl, v.grid.Hvbr, v.grid.Hvb
 	}
-	// Return an empty string and an error if c is negative
-	if c < 0 {
-		return "", tserr.Higher(&tserr.HigherArgs{Var: "row index", Actual: int64(c), LowerBound: 0})
+	// Return the grid rune for column c
+	switch c {
+	case 0:
+		return tsfio.RuneToPrintable(first)
+	case cmax:
+		return tsfio.RuneToPrintable(last)
+	default:
+		return tsfio.RuneToPrintable(inner)
 	}
-	// Return an empty string and an error if header is nil
-	if t.header == nil {
-		return "", tserr.NilPtr()
+}
+
+// vline returns the vertical grid line left of column c of view v as a string. Column c equal to the number of
+// columns is the end of the line.
+func (v *View) vline(c int) string {
+	// Return the vertical border grid line rune for the first and the last column
+	if (c == 0) || (c == len(v.width)) {
+		return tsfio.RuneToPrintable(v.grid.Vb)
 	}
-	spaces, e := t.spaces()
-	if e != nil {
-		return "", tserr.Op(&tserr.OpArgs{Op: "spaces", Fn: "table", Err: e})
+	// Return the vertical grid line rune
+	return tsfio.RuneToPrintable(v.grid.Vi)
+}
+
+// Weights of grid lines
+const (
+	lineNone   int = iota // No visible line
+	lineLight             // Light line
+	lineBold              // Bold line
+	lineDouble            // Double line
+)
+
+// A gridLine classifies a grid rune as a line with a weight and a number of dashes, independent of the output format.
+type gridLine struct {
+	weight int // Weight of the line, lineNone if the grid rune is not drawn
+	dashes int // Number of dashes of the box drawing rune, zero for a solid line
+}
+
+// gridLineOf returns the line of grid rune g. Double lines, bold lines as well as dashed and dotted lines of the box
+// drawing block are classified by their weight and dashes. Any other printable rune is a light solid line. Empty and
+// non-printable runes are classified as lineNone.
+func gridLineOf(g rune) gridLine {
+	switch g {
+	// Double lines
+	case '═', '║':
+		return gridLine{weight: lineDouble}
+	// Bold lines
+	case '━', '┃':
+		return gridLine{weight: lineBold}
+	// Light and bold double dash lines
+	case '╌', '╎':
+		return gridLine{weight: lineLight, dashes: 2}
+	case '╍', '╏':
+		return gridLine{weight: lineBold, dashes: 2}
+	// Light and bold triple dash lines
+	case '┄', '┆':
+		return gridLine{weight: lineLight, dashes: 3}
+	case '┅', '┇':
+		return gridLine{weight: lineBold, dashes: 3}
+	// Light and bold quadruple dash lines
+	case '┈', '┊':
+		return gridLine{weight: lineLight, dashes: 4}
+	case '┉', '┋':
+		return gridLine{weight: lineBold, dashes: 4}
 	}
-	// Maximum number of vertical grid lines
-	cmax := len(t.header)
-	// Return an empty string and an error if c is higher than cmax
-	if c > cmax {
-		return "", tserr.Lower(&tserr.LowerArgs{Var: "column index", Actual: int64(c), HigherBound: int64(cmax)})
-	}
-	// Set vertical grid line
-	v_rune := t.grid.Vi
-	// Update vertical grid line for the first or last column
-	if (c == 0) || (c == cmax) {
-		v_rune = t.grid.Vb
-	}
-	// Return vertical grid line with padding and nil
-	return spaces + tsfio.RuneToPrintable(v_rune), nil
+	// Return no line for empty or non-printable runes
+	if (g == 0) || !unicode.IsPrint(g) || unicode.IsSpace(g) {
+		return gridLine{}
+	}
+	// Return a light line for any other rune
+	return gridLine{weight: lineLight}
 }
@@ -0,0 +1,134 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable
+
+// Import Go standard packages as well as tserr and tsfio
+import (
+	"strconv" // strconv
+	"strings" // strings
+	"unicode" // unicode
+
+	"github.com/thorstenrie/tserr" // tserr
+	"github.com/thorstenrie/tsfio" // tsfio
+)
+
+// A NonPrintable policy defines the handling of non-printable runes, like newlines or tabs, in cells of imported tables.
+type NonPrintable int
+
+const (
+	NonPrintableError   NonPrintable = iota // Return an error like AddRow (default)
+	NonPrintableDrop                        // Drop non-printable runes
+	NonPrintableReplace                     // Replace each non-printable rune with a space
+	NonPrintableSkip                        // Skip rows containing non-printable runes
+)
+
+// A Ragged policy defines the handling of imported rows with a number of cells different from the header.
+// RaggedPad and RaggedTruncate can be combined.
+type Ragged int
+
+const (
+	RaggedError    Ragged = 0                          // Return an error (default)
+	RaggedPad      Ragged = 1 << 0                     // Pad short rows with empty cells
+	RaggedTruncate Ragged = 1 << 1                     // Truncate long rows
+	RaggedFit             = RaggedPad | RaggedTruncate // Pad short rows and truncate long rows
+)
+
+// printable returns row r with non-printable runes handled according to policy p. The returned bool is false,
+// if the row is skipped. It returns nil, false and an error, if r contains non-printable runes and p is
+// NonPrintableError or if p is unknown.
+func printable(r []string, p NonPrintable) ([]string, bool, error) {
+	// Return nil, false and an error, if the policy is unknown
+	if (p < NonPrintableError) || (p > NonPrintableSkip) {
+		return nil, false, tserr.NotExistent("non-printable policy " + strconv.Itoa(int(p)))
+	}
+	// Return r, if all cells of r are printable
+	if ok, _ := tsfio.IsPrintable(r); ok || (len(r) == 0) {
+		return r, true, nil
+	}
+	// Handle non-printable runes according to policy p
+	switch p {
+	case NonPrintableDrop:
+		c := make([]string, len(r))
+		for i, v := range r {
+			c[i] = tsfio.Printable(v)
+		}
+		return c, true, nil
+	case NonPrintableReplace:
+		c := make([]string, len(r))
+		for i, v := range r {
+			c[i] = strings.Map(func(u rune) rune {
+				if unicode.IsPrint(u) {
+					return u
+				}
+				return ' '
+			}, v)
+		}
+		return c, true, nil
+	case NonPrintableSkip:
+		return nil, false, nil
+	default:
+		return nil, false, tserr.NonPrintable("row")
+	}
+}
+
+// fit returns row r fitted to n cells according to policy p. It returns nil and an error, if the number of cells of r
+// does not equal n and p does not allow to pad or truncate r.
+func fit(r []string, n int, p Ragged) ([]string, error) {
+	switch {
+	case (len(r) < n) && (p&RaggedPad != 0):
+		// Pad short row with empty cells
+		return append(r, make([]string, n-len(r))...), nil
+	case (len(r) > n) && (p&RaggedTruncate != 0):
+		// Truncate long row
+		return r[:n], nil
+	case len(r) != n:
+		// Return nil and an error, if the number of cells does not equal n
+		return nil, tserr.Equal(&tserr.EqualArgs{Var: "row", Actual: int64(len(r)), Want: int64(n)})
+	}
+	// Return r
+	return r, nil
+}
+
+// newImport returns a new table with header h, in which non-printable runes are handled according to policy p.
+// It returns nil and an error, if h is empty or if New fails.
+func newImport(h []string, p NonPrintable) (*Table, error) {
+	// Handle non-printable runes of the header. A header cannot be skipped.
+	if p == NonPrintableSkip {
+		p = NonPrintableError
+	}
+	h, _, e := printable(h, p)
+	// Return nil and an error, if printable fails
+	if e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "printable", Fn: "header", Err: e})
+	}
+	// Retrieve new table with header h
+	t, e := New(h)
+	// Return nil and an error, if New fails
+	if e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "New", Fn: "table", Err: e})
+	}
+	// Return table
+	return t, nil
+}
+
+// addImport adds row r to table t. Non-printable runes are handled according to policy p. It returns an error,
+// if printable or AddRow fail.
+func (t *Table) addImport(r []string, p NonPrintable) error {
+	// Handle non-printable runes of the row
+	r, ok, e := printable(r, p)
+	// Return an error, if printable fails
+	if e != nil {
+		return tserr.Op(&tserr.OpArgs{Op: "printable", Fn: "row", Err: e})
+	}
+	// Skip the row, if requested by the policy
+	if !ok {
+		return nil
+	}
+	// Add the row to the table
+	if e := t.AddRow(r); e != nil {
+		return tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: "table", Err: e})
+	}
+	// Return nil
+	return nil
+}
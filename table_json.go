@@ -0,0 +1,431 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable
+
+// Import Go standard packages and tserr
+import (
+	"bytes"         // bytes
+	"encoding/json" // json
+	"errors"        // errors
+	"io"            // io
+	"strconv"       // strconv
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// A JSONLayout defines the structure of the JSON representation of a table.
+type JSONLayout int
+
+const (
+	// JSONObjects represents the table as an array of objects. Each row is an object with the header
+	// names as keys in the order of the table header.
+	JSONObjects JSONLayout = iota
+	// JSONArrays represents the table as a document {"header": [...], "rows": [[...], ...]}.
+	JSONArrays
+)
+
+// JSONArgs holds the arguments for the JSON representation of a table.
+//
+//	Layout:	the JSON layout, JSONObjects or JSONArrays
+//	Indent:	indentation for pretty-printing, the output is compact if Indent is empty
+//	Unique:	rename duplicate header names in layout JSONObjects by appending _2, _3, ...
+//
+// In layout JSONObjects, header names are object keys. If the header contains duplicate names and
+// Unique is false, an error is returned. Layout JSONArrays supports duplicate header names.
+type JSONArgs struct {
+	Layout JSONLayout // JSON layout
+	Indent string     // Indentation for pretty-printing
+	Unique bool       // Rename duplicate header names
+}
+
+// MarshalJSON implements the json.Marshaler interface. It returns table t as a compact
+// JSON array of objects keyed by header name. It returns an error if the header contains duplicate names.
+func (t *Table) MarshalJSON() ([]byte, error) {
+	// Return nil and an error if t is nil
+	if t == nil {
+		return nil, tserr.NilPtr()
+	}
+	// Retrieve the JSON representation of t as an array of objects
+	s, e := t.JSON(&JSONArgs{Layout: JSONObjects})
+	// Return nil and an error if JSON fails
+	if e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "JSON", Fn: "table", Err: e})
+	}
+	// Return the JSON representation of t
+	return []byte(s), nil
+}
+
+// JSON returns table t in a JSON representation defined by a. If a is nil, the table is returned as a compact
+// array of objects. The rows are sorted in the same order as with Print. It returns an empty string and an
+// error, if any.
+func (t *Table) JSON(a *JSONArgs) (string, error) {
+	// Retrieve view of t
+	v, e := t.view()
+	// Return an empty string and an error if view fails
+	if e != nil {
+		return "", tserr.Op(&tserr.OpArgs{Op: "view", Fn: "table", Err: e})
+	}
+	// Return JSON representation
+	return v.json(a)
+}
+
+// json returns view v in a JSON representation defined by a.
+func (v *View) json(a *JSONArgs) (string, error) {
+	// Use default arguments, if a is nil
+	if a == nil {
+		a = &JSONArgs{}
+	}
+	var (
+		b bytes.Buffer // JSON output buffer
+		e error        // Error
+	)
+	// Write the table in the selected layout
+	switch a.Layout {
+	case JSONObjects:
+		e = v.jsonObjects(&b, a.Unique)
+	case JSONArrays:
+		e = v.jsonArrays(&b)
+	default:
+		return "", tserr.NotExistent("JSON layout " + strconv.Itoa(int(a.Layout)))
+	}
+	// Return an empty string and an error, if writing the JSON representation fails
+	if e != nil {
+		return "", e
+	}
+	// Return the compact JSON representation, if no indentation is provided
+	if a.Indent == "" {
+		return b.String(), nil
+	}
+	// Indent the JSON representation
+	var ib bytes.Buffer
+	if e := json.Indent(&ib, b.Bytes(), "", a.Indent); e != nil {
+		return "", tserr.Op(&tserr.OpArgs{Op: "Indent", Fn: "JSON", Err: e})
+	}
+	// Return the indented JSON representation
+	return ib.String(), nil
+}
+
+// jsonKeys returns the header names of view v as object keys. If unique is true, duplicate header names
+// are renamed by appending _2, _3, ... Otherwise, it returns nil and an error if the header contains duplicate names.
+func (v *View) jsonKeys(unique bool) ([]string, error) {
+	// Allocate keys, set of seen header names and set of taken keys
+	keys, seen, taken := make([]string, len(v.header)), make(map[string]bool, len(v.header)), make(map[string]bool, len(v.header))
+	// Mark all header names as taken, so that renamed keys do not collide with subsequent header names
+	for _, h := range v.header {
+		taken[h] = true
+	}
+	// Iterate all header names
+	for i, h := range v.header {
+		k := h
+		// Handle a duplicate header name
+		if seen[h] {
+			// Return nil and an error, if duplicates are not renamed
+			if !unique {
+				return nil, tserr.Duplicate("header " + h)
+			}
+			// Append the lowest suffix resulting in a key which is not taken
+			for n := 2; taken[k]; n++ {
+				k = h + "_" + strconv.Itoa(n)
+			}
+		}
+		// Set key and mark header name as seen and key as taken
+		keys[i], seen[h], taken[k] = k, true, true
+	}
+	// Return keys
+	return keys, nil
+}
+
+// jsonObjects writes the rows of view v as a JSON array of objects to b.
+func (v *View) jsonObjects(b *bytes.Buffer, unique bool) error {
+	// Retrieve header names as object keys
+	keys, e := v.jsonKeys(unique)
+	if e != nil {
+		return tserr.Op(&tserr.OpArgs{Op: "jsonKeys", Fn: "header", Err: e})
+	}
+	b.WriteByte('[')
+	// Iterate all rows
+	for i, r := range v.rows {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('{')
+		// Write each cell of row r with its key
+		for j, c := range r {
+			if j > 0 {
+				b.WriteByte(',')
+			}
+			jsonString(b, keys[j])
+			b.WriteByte(':')
+			jsonString(b, c)
+		}
+		b.WriteByte('}')
+	}
+	b.WriteByte(']')
+	// Return nil
+	return nil
+}
+
+// jsonArrays writes view v as a JSON document with the header and the rows as arrays to b.
+func (v *View) jsonArrays(b *bytes.Buffer) error {
+	b.WriteString(`{"header":`)
+	jsonArray(b, v.header)
+	b.WriteString(`,"rows":[`)
+	// Iterate all rows
+	for i, r := range v.rows {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		jsonArray(b, r)
+	}
+	b.WriteString("]}")
+	// Return nil
+	return nil
+}
+
+// jsonArray writes the slice of strings s as a JSON array to b.
+func jsonArray(b *bytes.Buffer, s []string) {
+	b.WriteByte('[')
+	for i, c := range s {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		jsonString(b, c)
+	}
+	b.WriteByte(']')
+}
+
+// jsonString writes s as a JSON string to b. In contrast to json.Marshal, HTML characters are not escaped.
+func jsonString(b *bytes.Buffer, s string) {
+	// Encode with HTML escaping disabled
+	var eb bytes.Buffer
+	enc := json.NewEncoder(&eb)
+	enc.SetEscapeHTML(false)
+	// Encoding a string cannot fail
+	_ = enc.Encode(s)
+	// Write encoded string without the trailing newline added by Encode
+	b.Write(bytes.TrimSuffix(eb.Bytes(), []byte("\n")))
+}
+
+// ReadJSONArgs holds the arguments for reading a table from JSON.
+//
+//	Depth:		maximum number of nested levels of objects flattened into columns, 0 for no limit and -1 for none
+//	Header:		keys in the order of the first columns, other keys follow in first-seen order
+//	Missing:	placeholder for keys missing in an object (default empty)
+//	NonPrintable:	handling of non-printable runes in cells (default NonPrintableError)
+type ReadJSONArgs struct {
+	Depth        int          // Maximum number of flattened levels
+	Header       []string     // Order of the first columns
+	Missing      string       // Placeholder for missing keys
+	NonPrintable NonPrintable // Policy for non-printable runes
+}
+
+// A jsonMember is a member of a JSON object with its key and value.
+type jsonMember struct {
+	key string // Key of the member
+	val any    // Value of the member
+}
+
+// ReadJSON returns a new Table read from a JSON array of objects in r. Each object is a row. The header is the union of
+// the keys in the order they are first seen, like with FromMaps. Nested objects are flattened into columns with dotted
+// names, for example meta.owner, up to Depth levels. Arrays and objects below Depth are compact JSON cells. Strings,
+// numbers and booleans are cells with their value and null is an empty cell. If a is nil, default values are used.
+// It returns nil and an error, if r is not a JSON array of objects, if the array is followed by other data than
+// whitespace or if the table cannot be created.
+func ReadJSON(r io.Reader, a *ReadJSONArgs) (*Table, error) {
+	// Return nil and an error if r is nil
+	if r == nil {
+		return nil, tserr.NilPtr()
+	}
+	// Decode JSON value
+	d := json.NewDecoder(r)
+	d.UseNumber()
+	v, e := jsonDecode(d)
+	if e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "decode", Fn: "JSON", Err: e})
+	}
+	// Return nil and an error, if the JSON value is followed by other data than whitespace
+	if _, e := d.Token(); !errors.Is(e, io.EOF) {
+		return nil, tserr.TypeNotMatching(&tserr.TypeNotMatchingArgs{Act: "data after the JSON value", Want: "end of JSON"})
+	}
+	// Return nil and an error, if the value is not an array
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, tserr.TypeNotMatching(&tserr.TypeNotMatchingArgs{Act: "JSON value", Want: "array"})
+	}
+	// Return table from objects
+	return jsonTable(arr, a)
+}
+
+// ReadNDJSON returns a new Table read from newline-delimited JSON in r. Each line is an object and a row of the table.
+// Objects are converted like with ReadJSON. Errors refer to the number of the JSON value, which equals the line number,
+// if r has neither empty lines nor values spanning several lines. It returns nil and an error, if a value is not a JSON
+// object or if the table cannot be created.
+func ReadNDJSON(r io.Reader, a *ReadJSONArgs) (*Table, error) {
+	// Return nil and an error if r is nil
+	if r == nil {
+		return nil, tserr.NilPtr()
+	}
+	// Decode JSON values until the end of r
+	d := json.NewDecoder(r)
+	d.UseNumber()
+	var arr []any
+	for {
+		v, e := jsonDecode(d)
+		if errors.Is(e, io.EOF) {
+			break
+		}
+		// Return nil and an error, if decoding fails
+		if e != nil {
+			return nil, tserr.Op(&tserr.OpArgs{Op: "decode", Fn: "NDJSON value " + strconv.Itoa(len(arr)+1), Err: e})
+		}
+		arr = append(arr, v)
+	}
+	// Return table from objects
+	return jsonTable(arr, a)
+}
+
+// jsonTable returns a new Table with a row for each object of arr. It returns nil and an error, if an element of
+// arr is not an object or if the table cannot be created.
+func jsonTable(arr []any, a *ReadJSONArgs) (*Table, error) {
+	// Use default arguments, if a is nil
+	if a == nil {
+		a = &ReadJSONArgs{}
+	}
+	// Flatten objects into records
+	recs := make([][]field, len(arr))
+	for i, v := range arr {
+		obj, ok := v.([]jsonMember)
+		// Return nil and an error, if the element is not an object
+		if !ok {
+			return nil, tserr.TypeNotMatching(&tserr.TypeNotMatchingArgs{Act: "JSON element " + strconv.Itoa(i), Want: "object"})
+		}
+		jsonFlatten(&recs[i], "", obj, 0, a.Depth)
+	}
+	// Return table from records
+	return fromRecords(recs, &MapArgs{Header: a.Header, Missing: a.Missing, NonPrintable: a.NonPrintable})
+}
+
+// jsonDecode returns the next JSON value of d. Objects are returned as a slice of members in the order of the
+// document, arrays as a slice of values and numbers as json.Number. It returns nil and an error, if decoding fails.
+func jsonDecode(d *json.Decoder) (any, error) {
+	// Retrieve next token
+	t, e := d.Token()
+	if e != nil {
+		return nil, e
+	}
+	switch t {
+	case json.Delim('{'):
+		// Decode members of an object
+		obj := make([]jsonMember, 0)
+		for d.More() {
+			k, e := d.Token()
+			if e != nil {
+				return nil, jsonUnexpected(e)
+			}
+			v, e := jsonDecode(d)
+			if e != nil {
+				return nil, jsonUnexpected(e)
+			}
+			obj = append(obj, jsonMember{key: k.(string), val: v})
+		}
+		// Consume closing delimiter
+		if _, e := d.Token(); e != nil {
+			return nil, jsonUnexpected(e)
+		}
+		return obj, nil
+	case json.Delim('['):
+		// Decode values of an array
+		arr := make([]any, 0)
+		for d.More() {
+			v, e := jsonDecode(d)
+			if e != nil {
+				return nil, jsonUnexpected(e)
+			}
+			arr = append(arr, v)
+		}
+		// Consume closing delimiter
+		if _, e := d.Token(); e != nil {
+			return nil, jsonUnexpected(e)
+		}
+		return arr, nil
+	}
+	// Return string, number, bool or nil
+	return t, nil
+}
+
+// jsonUnexpected returns io.ErrUnexpectedEOF, if e is io.EOF. Otherwise, it returns e.
+func jsonUnexpected(e error) error {
+	if errors.Is(e, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return e
+}
+
+// jsonFlatten appends the members of object obj to record r. Keys are prefixed with prefix. Nested objects are flattened
+// into dotted keys, if level is lower than depth or if depth is 0.
+func jsonFlatten(r *[]field, prefix string, obj []jsonMember, level, depth int) {
+	for _, m := range obj {
+		k := prefix + m.key
+		// Flatten nested objects
+		if sub, ok := m.val.([]jsonMember); ok && (len(sub) > 0) && ((depth == 0) || (level < depth)) {
+			jsonFlatten(r, k+".", sub, level+1, depth)
+			continue
+		}
+		// Append value as cell
+		*r = append(*r, field{key: k, cell: jsonCell(m.val)})
+	}
+}
+
+// jsonCell returns JSON value v as a cell. Strings are returned without quotes, null as an empty cell and arrays and
+// objects as compact JSON.
+func jsonCell(v any) string {
+	switch c := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return c
+	case json.Number:
+		return c.String()
+	case bool:
+		return strconv.FormatBool(c)
+	}
+	// Return arrays and objects as compact JSON
+	var b bytes.Buffer
+	jsonCompact(&b, v)
+	return b.String()
+}
+
+// jsonCompact writes JSON value v as compact JSON to b.
+func jsonCompact(b *bytes.Buffer, v any) {
+	switch c := v.(type) {
+	case nil:
+		b.WriteString("null")
+	case string:
+		jsonString(b, c)
+	case json.Number:
+		b.WriteString(c.String())
+	case bool:
+		b.WriteString(strconv.FormatBool(c))
+	case []any:
+		b.WriteByte('[')
+		for i, e := range c {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			jsonCompact(b, e)
+		}
+		b.WriteByte(']')
+	case []jsonMember:
+		b.WriteByte('{')
+		for i, m := range c {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			jsonString(b, m.key)
+			b.WriteByte(':')
+			jsonCompact(b, m.val)
+		}
+		b.WriteByte('}')
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable_test
+
+// Import Go standard library packages as well as tstable and tserr
+import (
+	"encoding/json" // json
+	"strconv"       // strconv
+	"strings"       // strings
+	"testing"       // testing
+
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tstable" // tstable
+)
+
+// TestJSON tests the JSON representations of the test table. The test fails if a JSON representation
+// does not equal to the contents of the test data golden file.
+func TestJSON(t *testing.T) {
+	// Test cases with golden file names and JSON arguments
+	tc := map[string]*tstable.JSONArgs{
+		"JSONObjects":       {Layout: tstable.JSONObjects},
+		"JSONObjectsIndent": {Layout: tstable.JSONObjects, Indent: "  "},
+		"JSONArrays":        {Layout: tstable.JSONArrays},
+		"JSONArraysIndent":  {Layout: tstable.JSONArrays, Indent: "\t"},
+	}
+	// Retrieve test table
+	tbl := testTable(t)
+	// Iterate all test cases
+	for name, a := range tc {
+		// Retrieve JSON representation
+		s, e := tbl.JSON(a)
+		// The test fails if JSON returns an error
+		if e != nil {
+			t.Error(tserr.Op(&tserr.OpArgs{Op: "JSON", Fn: name, Err: e}))
+		}
+		// Evaluate the JSON representation
+		evalGolden(name, s, t)
+	}
+}
+
+// TestMarshalJSON tests the implementation of json.Marshaler for a Table. The test fails if the
+// result of json.Marshal does not equal to the JSON representation as an array of objects.
+func TestMarshalJSON(t *testing.T) {
+	// Retrieve test table
+	tbl := testTable(t)
+	// Marshal the test table
+	b, e := json.Marshal(tbl)
+	// The test fails if Marshal returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Marshal", Fn: "table", Err: e}))
+	}
+	// Evaluate the JSON representation
+	evalGolden("JSONObjects", string(b), t)
+}
+
+// TestJSONDuplicate tests the JSON representation of a table with duplicate header names. The test fails
+// if layout JSONObjects does not return an error or if renamed keys do not match.
+func TestJSONDuplicate(t *testing.T) {
+	// Retrieve new test table with a duplicate header name
+	tbl, e := tstable.New([]string{"a", "b", "a", "a_2"})
+	// The test fails if New returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "New", Fn: "table", Err: e}))
+	}
+	// Add a row to the table
+	if e := tbl.AddRow([]string{"1", "2", "3", "4"}); e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: "table", Err: e}))
+	}
+	// The test fails if JSON returns a nil error for duplicate keys
+	if _, e := tbl.JSON(nil); e == nil {
+		t.Error(tserr.NilFailed("JSON"))
+	}
+	// Retrieve JSON representation with renamed duplicate keys
+	s, e := tbl.JSON(&tstable.JSONArgs{Unique: true})
+	// The test fails if JSON returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "JSON", Fn: "table", Err: e}))
+	}
+	// The test fails if the JSON representation does not match
+	want := `[{"a":"1","b":"2","a_3":"3","a_2":"4"}]`
+	if s != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "JSON", Actual: s, Want: want}))
+	}
+}
+
+// testReadJSON is a JSON array of objects with nested objects, arrays, null and missing keys
+var testReadJSON = `[
+	{"id": 1, "meta": {"owner": "ops", "labels": {"env": "prod"}}, "tags": ["a", "b"], "ok": true},
+	{"id": 2, "meta": {"owner": "dev"}, "note": null, "extra": {}}
+]`
+
+// TestReadJSON tests reading a JSON array of objects with different depths. The test fails if ReadJSON returns
+// an error or if the Markdown representation does not match.
+func TestReadJSON(t *testing.T) {
+	for _, c := range []struct {
+		depth int    // Depth of flattening
+		want  string // Markdown representation
+	}{
+		{0, "| id | meta.owner | meta.labels.env | tags | ok | note | extra |\n| --- | --- | --- | --- | --- | --- | --- |\n" +
+			"| 1 | ops | prod | [\"a\",\"b\"] | true | - | - |\n| 2 | dev | - | - | - |  | {} |\n"},
+		{1, "| id | meta.owner | meta.labels | tags | ok | note | extra |\n| --- | --- | --- | --- | --- | --- | --- |\n" +
+			"| 1 | ops | {\"env\":\"prod\"} | [\"a\",\"b\"] | true | - | - |\n| 2 | dev | - | - | - |  | {} |\n"},
+		{-1, "| id | meta | tags | ok | note | extra |\n| --- | --- | --- | --- | --- | --- |\n" +
+			"| 1 | {\"owner\":\"ops\",\"labels\":{\"env\":\"prod\"}} | [\"a\",\"b\"] | true | - | - |\n| 2 | {\"owner\":\"dev\"} | - | - |  | {} |\n"},
+	} {
+		// Read table from JSON
+		tbl, e := tstable.ReadJSON(strings.NewReader(testReadJSON), &tstable.ReadJSONArgs{Depth: c.depth, Missing: "-"})
+		if e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ReadJSON", Fn: "array", Err: e}))
+		}
+		// The test fails if the Markdown representation does not match
+		if s, _ := tbl.Markdown(); s != c.want {
+			t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "ReadJSON depth " + strconv.Itoa(c.depth), Actual: s, Want: c.want}))
+		}
+	}
+}
+
+// TestReadNDJSON tests reading newline-delimited JSON with a provided first column. The test fails if ReadNDJSON
+// returns an error or if the Markdown representation does not match.
+func TestReadNDJSON(t *testing.T) {
+	s := "{\"msg\":\"start\",\"level\":\"info\"}\n\n{\"level\":\"warn\",\"msg\":\"slow\",\"ms\":1.5e3}\n"
+	// Read table from NDJSON
+	tbl, e := tstable.ReadNDJSON(strings.NewReader(s), &tstable.ReadJSONArgs{Header: []string{"level"}})
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ReadNDJSON", Fn: "lines", Err: e}))
+	}
+	// The test fails if the Markdown representation does not match
+	want := "| level | msg | ms |\n| --- | --- | --- |\n| info | start |  |\n| warn | slow | 1.5e3 |\n"
+	if m, _ := tbl.Markdown(); m != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "ReadNDJSON", Actual: m, Want: want}))
+	}
+}
+
+// TestReadJSONErr tests ReadJSON and ReadNDJSON with invalid input and ReadJSON with data after the array. The test
+// fails if no error is returned or if the error of ReadNDJSON does not refer to the value.
+func TestReadJSONErr(t *testing.T) {
+	// The test fails if ReadJSON does not return an error for invalid input
+	for _, s := range []string{"", "{\"a\":1}", "[1]", "[{\"a\":1}", "[{\"a\":\"\\n\"}]", "[{\"a\":1}] x", "[{\"a\":1}]]", "[{\"a\":1}]\n{}"} {
+		if _, e := tstable.ReadJSON(strings.NewReader(s), nil); e == nil {
+			t.Error(tserr.NilFailed("ReadJSON " + s))
+		}
+	}
+	// The test fails if ReadNDJSON does not return an error for invalid input
+	for _, s := range []string{"", "[]\n", "{\"a\":1}\n{\"a\":"} {
+		if _, e := tstable.ReadNDJSON(strings.NewReader(s), nil); e == nil {
+			t.Error(tserr.NilFailed("ReadNDJSON " + s))
+		}
+	}
+	// The test fails if the error of ReadNDJSON does not refer to the second value after an empty line
+	if _, e := tstable.ReadNDJSON(strings.NewReader("{\"a\":1}\n\n{\"a\":"), nil); (e == nil) || !strings.Contains(e.Error(), "NDJSON value 2") {
+		t.Error(tserr.NotExistent("NDJSON value 2"))
+	}
+	// The test fails if ReadJSON does not return an error for a nil reader
+	if _, e := tstable.ReadJSON(nil, nil); e == nil {
+		t.Error(tserr.NilFailed("ReadJSON"))
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable
+
+// Import Go standard package strings and tserr
+import (
+	"strings" // strings
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// LaTeXArgs holds the arguments for the LaTeX representation of a table.
+//
+//	Booktabs:	use \toprule, \midrule and \bottomrule of package booktabs instead of \hline
+type LaTeXArgs struct {
+	Booktabs bool // Use booktabs rules
+}
+
+// latexEscaper escapes LaTeX special characters in cells
+var latexEscaper = strings.NewReplacer(
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+	`\`, `\textbackslash{}`,
+)
+
+// latexSpec maps a column alignment to its tabular column specifier
+var latexSpec = map[Alignment]byte{
+	AlignLeft:   'l',
+	AlignRight:  'r',
+	AlignCenter: 'c',
+}
+
+// LaTeX returns table t as a LaTeX tabular environment. The column specifiers are derived from the column
+// alignment. LaTeX special characters in cells are escaped. If a is nil, the table is separated
+// with \hline. The rows are sorted in the same order as with Print. It returns an empty string and an error, if any.
+func (t *Table) LaTeX(a *LaTeXArgs) (string, error) {
+	// Retrieve view of t
+	v, e := t.view()
+	// Return an empty string and an error if view fails
+	if e != nil {
+		return "", tserr.Op(&tserr.OpArgs{Op: "view", Fn: "table", Err: e})
+	}
+	// Return LaTeX representation
+	return v.latex(a)
+}
+
+// latex returns view v as a LaTeX tabular environment.
+func (v *View) latex(a *LaTeXArgs) (string, error) {
+	// Use default arguments, if a is nil
+	if a == nil {
+		a = &LaTeXArgs{}
+	}
+	// Set the horizontal rules
+	top, mid, bottom := `\hline`, `\hline`, `\hline`
+	if a.Booktabs {
+		top, mid, bottom = `\toprule`, `\midrule`, `\bottomrule`
+	}
+	var b strings.Builder
+	// Begin tabular environment with column specifiers
+	b.WriteString(`\begin{tabular}{`)
+	for i := range v.header {
+		b.WriteByte(latexSpec[v.Alignment(i)])
+	}
+	b.WriteString("}\n" + top + "\n")
+	// Write header
+	latexRow(&b, v.header)
+	b.WriteString(mid + "\n")
+	// Write rows
+	for _, r := range v.rows {
+		latexRow(&b, r)
+	}
+	// End tabular environment
+	b.WriteString(bottom + "\n" + `\end{tabular}` + "\n")
+	// Return LaTeX representation
+	return b.String(), nil
+}
+
+// latexRow writes row r with escaped cells as a tabular row to b.
+func latexRow(b *strings.Builder, r []string) {
+	for i, c := range r {
+		if i > 0 {
+			b.WriteString(" & ")
+		}
+		b.WriteString(latexEscaper.Replace(c))
+	}
+	b.WriteString(` \\` + "\n")
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable_test
+
+// Import Go standard library package testing as well as tstable and tserr
+import (
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tstable" // tstable
+)
+
+// TestLaTeX tests the LaTeX representations of the test table with a right-aligned and a centered column.
+// The test fails if a LaTeX representation does not equal to the contents of the test data golden file.
+func TestLaTeX(t *testing.T) {
+	// Test cases with golden file names and LaTeX arguments
+	tc := map[string]*tstable.LaTeXArgs{
+		"LaTeX":         nil,
+		"LaTeXBooktabs": {Booktabs: true},
+	}
+	// Retrieve test table with aligned columns
+	tbl := testAlignedTable(t)
+	// Iterate all test cases
+	for name, a := range tc {
+		// Retrieve LaTeX representation
+		s, e := tbl.LaTeX(a)
+		// The test fails if LaTeX returns an error
+		if e != nil {
+			t.Error(tserr.Op(&tserr.OpArgs{Op: "LaTeX", Fn: name, Err: e}))
+		}
+		// Evaluate the LaTeX representation
+		evalGolden(name, s, t)
+	}
+}
+
+// TestLaTeXEscape tests escaping of LaTeX special characters. The test fails if the escaped
+// cell does not match.
+func TestLaTeXEscape(t *testing.T) {
+	// Retrieve new test table
+	tbl, e := tstable.New([]string{"a"})
+	// The test fails if New returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "New", Fn: "table", Err: e}))
+	}
+	// Add a row with all special characters
+	if e := tbl.AddRow([]string{`& % $ # _ { } ~ ^ \`}); e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: "table", Err: e}))
+	}
+	// Retrieve LaTeX representation
+	s, e := tbl.LaTeX(nil)
+	// The test fails if LaTeX returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "LaTeX", Fn: "table", Err: e}))
+	}
+	// The test fails if the LaTeX representation does not match
+	want := "\\begin{tabular}{l}\n\\hline\na \\\\\n\\hline\n" +
+		`\& \% \$ \# \_ \{ \} \textasciitilde{} \textasciicircum{} \textbackslash{} \\` +
+		"\n\\hline\n\\end{tabular}\n"
+	if s != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "LaTeX", Actual: s, Want: want}))
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable
+
+// Import Go standard packages and tserr
+import (
+	"reflect" // reflect
+	"sort"    // sort
+	"strconv" // strconv
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// MapArgs holds the arguments for tables from slices of maps.
+//
+//	Header:		keys in the order of the first columns, other keys follow in first-seen order
+//	Missing:	placeholder for keys missing in a map (default empty)
+//	NonPrintable:	handling of non-printable runes in cells (default NonPrintableError)
+type MapArgs struct {
+	Header       []string     // Order of the first columns
+	Missing      string       // Placeholder for missing keys
+	NonPrintable NonPrintable // Policy for non-printable runes
+}
+
+// FromMaps returns a new Table with a row for each map of m. The header is the union of the keys of all maps. The keys
+// of Header in a are the first columns in the provided order. All other keys follow in the order they are first seen
+// in m, in which keys of the same map are sorted. Keys missing in a map are filled with the placeholder Missing. Values
+// are converted to cells like the fields with FromStructs: pointers are dereferenced, nil values are empty cells and
+// time.Time, time.Duration and fmt.Stringer are converted with their methods. Non-printable runes are handled according
+// to the NonPrintable policy in a. If a is nil, default values are used. It returns nil and an error, if the header is
+// empty, if Header contains duplicates or if a row cannot be added.
+func FromMaps[V any](m []map[string]V, a *MapArgs) (*Table, error) {
+	// Use default arguments, if a is nil
+	if a == nil {
+		a = &MapArgs{}
+	}
+	// Conversion of values to cells with default options
+	c := structField{layout: structLayout}
+	// Retrieve records with sorted keys and values converted to cells
+	recs := make([][]field, len(m))
+	for i, r := range m {
+		recs[i] = make([]field, 0, len(r))
+		for k, val := range r {
+			recs[i] = append(recs[i], field{key: k, cell: c.cell(reflect.ValueOf(&val).Elem())})
+		}
+		sort.Slice(recs[i], func(x, y int) bool { return recs[i][x].key < recs[i][y].key })
+	}
+	// Return table from records
+	return fromRecords(recs, a)
+}
+
+// A field is a key with its value converted to a cell.
+type field struct {
+	key  string // Key of the field
+	cell string // Value of the field as cell
+}
+
+// fromRecords returns a new Table with a row for each record of recs. The keys of Header in a are the first columns in
+// the provided order. All other keys follow in the order they are first seen in recs. Keys missing in a record are filled
+// with the placeholder Missing. It returns nil and an error, if the header is empty, if Header contains duplicates or
+// if a row cannot be added.
+func fromRecords(recs [][]field, a *MapArgs) (*Table, error) {
+	// Retrieve header from keys in Header
+	h, idx := make([]string, 0, len(a.Header)), make(map[string]int)
+	for _, k := range a.Header {
+		// Return nil and an error, if Header contains a duplicate
+		if _, ok := idx[k]; ok {
+			return nil, tserr.Duplicate("key " + k)
+		}
+		idx[k] = len(h)
+		h = append(h, k)
+	}
+	// Append other keys in first-seen order
+	for _, r := range recs {
+		for _, f := range r {
+			if _, ok := idx[f.key]; !ok {
+				idx[f.key] = len(h)
+				h = append(h, f.key)
+			}
+		}
+	}
+	// Return nil and an error, if the header is empty
+	if len(h) == 0 {
+		return nil, tserr.Empty("keys")
+	}
+	// Retrieve new table with header h
+	t, e := newImport(h, a.NonPrintable)
+	if e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "newImport", Fn: "records", Err: e})
+	}
+	// Add a row for each record
+	for i, r := range recs {
+		// Fill row with placeholder for missing keys
+		row := make([]string, len(h))
+		for j := range row {
+			row[j] = a.Missing
+		}
+		// Set cells of the record
+		for _, f := range r {
+			row[idx[f.key]] = f.cell
+		}
+		// Add row to the table
+		if e := t.addImport(row, a.NonPrintable); e != nil {
+			return nil, tserr.Op(&tserr.OpArgs{Op: "addImport", Fn: "record " + strconv.Itoa(i), Err: e})
+		}
+	}
+	// Return table
+	return t, nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable_test
+
+// Import Go standard library packages as well as tstable and tserr
+import (
+	"testing" // testing
+	"time"    // time
+
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tstable" // tstable
+)
+
+// TestFromMaps tests a table from heterogeneous records with the header in first-seen order. The test fails if
+// FromMaps returns an error or if the Markdown representation does not match.
+func TestFromMaps(t *testing.T) {
+	// Records with different keys and value types
+	n := 3
+	m := []map[string]any{
+		{"msg": "started", "level": "info"},
+		{"msg": "slow", "level": "warn", "took": 2 * time.Second, "retries": &n},
+		{"msg": "stopped", "err": nil},
+	}
+	// Retrieve table with level first and a placeholder for missing keys
+	tbl, e := tstable.FromMaps(m, &tstable.MapArgs{Header: []string{"level"}, Missing: "-"})
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "FromMaps", Fn: "records", Err: e}))
+	}
+	// Retrieve Markdown representation
+	s, e := tbl.Markdown()
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Markdown", Fn: "table", Err: e}))
+	}
+	// The test fails if the Markdown representation does not match. Rows are sorted by column level.
+	want := "| level | msg | retries | took | err |\n" +
+		"| --- | --- | --- | --- | --- |\n" +
+		"| - | stopped | - | - |  |\n" +
+		"| info | started | - | - | - |\n" +
+		"| warn | slow | 3 | 2s | - |\n"
+	if s != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "FromMaps", Actual: s, Want: want}))
+	}
+}
+
+// TestFromStringMaps tests a table from string maps with default arguments. The test fails if FromMaps returns an
+// error or if the Markdown representation does not match.
+func TestFromStringMaps(t *testing.T) {
+	m := []map[string]string{{"b": "2", "a": "1"}, {"c": "3"}}
+	// Retrieve table
+	tbl, e := tstable.FromMaps(m, nil)
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "FromMaps", Fn: "records", Err: e}))
+	}
+	// The test fails if the Markdown representation does not match
+	want := "| a | b | c |\n| --- | --- | --- |\n|  |  | 3 |\n| 1 | 2 |  |\n"
+	if s, _ := tbl.Markdown(); s != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "FromMaps", Actual: s, Want: want}))
+	}
+}
+
+// TestFromMapsErr tests FromMaps without keys, with a duplicate in Header and with a non-printable value.
+// The test fails if FromMaps does not return an error.
+func TestFromMapsErr(t *testing.T) {
+	// The test fails if FromMaps does not return an error without keys
+	if _, e := tstable.FromMaps([]map[string]string{{}}, nil); e == nil {
+		t.Error(tserr.NilFailed("FromMaps"))
+	}
+	// The test fails if FromMaps does not return an error for a duplicate in Header
+	if _, e := tstable.FromMaps([]map[string]string{{"a": "1"}}, &tstable.MapArgs{Header: []string{"a", "a"}}); e == nil {
+		t.Error(tserr.NilFailed("FromMaps"))
+	}
+	// The test fails if FromMaps does not return an error for a non-printable value
+	if _, e := tstable.FromMaps([]map[string]string{{"a": "\t"}}, nil); e == nil {
+		t.Error(tserr.NilFailed("FromMaps"))
+	}
+}
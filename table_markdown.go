@@ -0,0 +1,212 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable
+
+// Import Go standard packages and tserr
+import (
+	"bufio"   // bufio
+	"io"      // io
+	"strconv" // strconv
+	"strings" // strings
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// markdownDelimiter maps a column alignment to its delimiter in the alignment row of a pipe table
+var markdownDelimiter = map[Alignment]string{
+	AlignLeft:   "---",
+	AlignRight:  "---:",
+	AlignCenter: ":---:",
+}
+
+// markdownEscaper escapes backslashes and the cell separator | in cells, so that a backslash in front of | stays in
+// the cell and does not escape the cell separator.
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, `|`, `\|`)
+
+// Markdown returns table t as a GitHub Flavored Markdown pipe table. The alignment row is derived from the column
+// alignment and backslashes as well as the cell separator | are escaped in cells. The rows are sorted in the same order
+// as with Print. It returns an empty string and an error, if any.
+func (t *Table) Markdown() (string, error) {
+	// Retrieve view of t
+	v, e := t.view()
+	// Return an empty string and an error if view fails
+	if e != nil {
+		return "", tserr.Op(&tserr.OpArgs{Op: "view", Fn: "table", Err: e})
+	}
+	// Return Markdown representation
+	return v.markdown()
+}
+
+// markdown returns view v as a GitHub Flavored Markdown pipe table.
+func (v *View) markdown() (string, error) {
+	var b strings.Builder
+	// Write header
+	markdownRow(&b, v.header)
+	// Write alignment row
+	d := make([]string, len(v.header))
+	for i := range d {
+		d[i] = markdownDelimiter[v.Alignment(i)]
+	}
+	b.WriteString("| " + strings.Join(d, " | ") + " |\n")
+	// Write rows
+	for _, r := range v.rows {
+		markdownRow(&b, r)
+	}
+	// Return Markdown representation
+	return b.String(), nil
+}
+
+// markdownRow writes row r with escaped cells to b.
+func markdownRow(b *strings.Builder, r []string) {
+	e := make([]string, len(r))
+	for i, c := range r {
+		e[i] = markdownEscaper.Replace(c)
+	}
+	b.WriteString("| " + strings.Join(e, " | ") + " |\n")
+}
+
+// ReadMarkdownArgs holds the arguments for reading a table in Markdown format. Empty fields are set to default values.
+//
+//	Ragged:		handling of rows with a number of cells different from the header (default RaggedFit, if nil)
+//	NonPrintable:	handling of non-printable runes in cells (default NonPrintableError)
+type ReadMarkdownArgs struct {
+	Ragged       *Ragged      // Policy for ragged rows
+	NonPrintable NonPrintable // Policy for non-printable runes
+}
+
+// ReadMarkdown returns a new Table read from the first GitHub Flavored Markdown pipe table in r. Lines before the table
+// are skipped. The table starts with the header followed by the alignment row and ends with an empty line or the end of
+// r. The alignment row sets the column alignment: ---: is right-aligned, :---: is centered and --- or :--- is
+// left-aligned. Leading and trailing pipes are optional, cells are trimmed of whitespace and escaped pipes \| and
+// backslashes \\ are unescaped. Rows with a number of cells different from the header are handled according to the
+// Ragged policy. Like GitHub Flavored Markdown, short rows are padded and long rows are truncated per default, which
+// equals RaggedFit. A pointer to RaggedError rejects ragged rows. If a is nil, default values are used. It returns nil
+// and an error, if r does not contain a pipe table or if a row cannot be added to the table.
+func ReadMarkdown(r io.Reader, a *ReadMarkdownArgs) (*Table, error) {
+	// Return nil and an error if r is nil
+	if r == nil {
+		return nil, tserr.NilPtr()
+	}
+	// Use default arguments, if a is nil
+	if a == nil {
+		a = &ReadMarkdownArgs{}
+	}
+	// Pad and truncate ragged rows like GitHub Flavored Markdown, if the Ragged policy is nil
+	ragged := RaggedFit
+	if a.Ragged != nil {
+		ragged = *a.Ragged
+	}
+	var (
+		s     = bufio.NewScanner(r) // Scanner of the lines of r
+		t     *Table                // Table read from r
+		h     []string              // Candidate header
+		align []Alignment           // Column alignment
+		line  int                   // Line number
+	)
+	// Read lines until the end of r or the end of the table
+	for s.Scan() {
+		line++
+		l := strings.TrimSpace(s.Text())
+		// Search the header followed by the alignment row
+		if t == nil {
+			c := markdownSplit(l)
+			if align = markdownAlignment(c); (h != nil) && (align != nil) && (len(align) == len(h)) {
+				// Retrieve new table with header h
+				var e error
+				if t, e = newImport(h, a.NonPrintable); e != nil {
+					return nil, tserr.Op(&tserr.OpArgs{Op: "newImport", Fn: "Markdown line " + strconv.Itoa(line-1), Err: e})
+				}
+				// Set column alignment
+				copy(t.align, align)
+				continue
+			}
+			// The line is a candidate header, if it contains a pipe
+			h = nil
+			if strings.Contains(l, "|") {
+				h = c
+			}
+			continue
+		}
+		// An empty line ends the table
+		if l == "" {
+			break
+		}
+		// Fit the row to the header
+		c, e := fit(markdownSplit(l), len(t.header), ragged)
+		if e != nil {
+			return nil, tserr.Op(&tserr.OpArgs{Op: "fit", Fn: "Markdown line " + strconv.Itoa(line), Err: e})
+		}
+		// Add the row to the table
+		if e := t.addImport(c, a.NonPrintable); e != nil {
+			return nil, tserr.Op(&tserr.OpArgs{Op: "addImport", Fn: "Markdown line " + strconv.Itoa(line), Err: e})
+		}
+	}
+	// Return nil and an error, if r cannot be read
+	if e := s.Err(); e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "Scan", Fn: "Markdown", Err: e})
+	}
+	// Return nil and an error, if r does not contain a pipe table
+	if t == nil {
+		return nil, tserr.NotExistent("Markdown pipe table")
+	}
+	// Return table
+	return t, nil
+}
+
+// markdownSplit returns the cells of line l of a pipe table. Leading and trailing pipes are removed, cells are
+// trimmed of whitespace and escaped pipes \| and backslashes \\ are unescaped.
+func markdownSplit(l string) []string {
+	// Remove leading pipe
+	l = strings.TrimPrefix(l, "|")
+	// Split l at unescaped pipes
+	var (
+		c   []string
+		b   strings.Builder
+		end bool // l ends with an unescaped pipe
+	)
+	for i := 0; i < len(l); i++ {
+		end = false
+		switch {
+		case (l[i] == '\\') && (i+1 < len(l)) && ((l[i+1] == '|') || (l[i+1] == '\\')):
+			b.WriteByte(l[i+1])
+			i++
+		case l[i] == '|':
+			c = append(c, strings.TrimSpace(b.String()))
+			b.Reset()
+			end = true
+		default:
+			b.WriteByte(l[i])
+		}
+	}
+	// Return cells, an unescaped trailing pipe ends the last cell
+	if end {
+		return c
+	}
+	return append(c, strings.TrimSpace(b.String()))
+}
+
+// markdownAlignment returns the column alignment of the cells c of an alignment row. It returns nil, if c is not
+// an alignment row.
+func markdownAlignment(c []string) []Alignment {
+	a := make([]Alignment, len(c))
+	for i, d := range c {
+		// Return nil, if d does not contain only dashes enclosed by optional colons
+		l, r := strings.HasPrefix(d, ":"), strings.HasSuffix(d, ":")
+		d = strings.TrimSuffix(strings.TrimPrefix(d, ":"), ":")
+		if (d == "") || (strings.Trim(d, "-") != "") {
+			return nil
+		}
+		// Retrieve alignment from the colons
+		switch {
+		case l && r:
+			a[i] = AlignCenter
+		case r:
+			a[i] = AlignRight
+		default:
+			a[i] = AlignLeft
+		}
+	}
+	// Return column alignment
+	return a
+}
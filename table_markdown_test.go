@@ -0,0 +1,163 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable_test
+
+// Import Go standard library packages as well as tstable and tserr
+import (
+	"fmt"     // fmt
+	"strings" // strings
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tstable" // tstable
+)
+
+// TestMarkdown tests the Markdown representation of the test table with a right-aligned and a centered column.
+// The test fails if the Markdown representation does not equal to the contents of the test data golden file.
+func TestMarkdown(t *testing.T) {
+	// Retrieve Markdown representation of test table with aligned columns
+	s, e := testAlignedTable(t).Markdown()
+	// The test fails if Markdown returns an error
+	if e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "Markdown", Fn: "table", Err: e}))
+	}
+	// Evaluate the Markdown representation
+	evalGolden("Markdown", s, t)
+}
+
+// TestMarkdownEscape tests escaping of the cell separator. The test fails if the escaped cell does not match.
+func TestMarkdownEscape(t *testing.T) {
+	// Retrieve new test table
+	tbl, e := tstable.New([]string{"a|b"})
+	// The test fails if New returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "New", Fn: "table", Err: e}))
+	}
+	// Retrieve Markdown representation
+	s, e := tbl.Markdown()
+	// The test fails if Markdown returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Markdown", Fn: "table", Err: e}))
+	}
+	// The test fails if the Markdown representation does not match
+	want := "| a\\|b |\n| --- |\n"
+	if s != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "Markdown", Actual: s, Want: want}))
+	}
+}
+
+// testReadMarkdown returns the table read from s with ReadMarkdown and arguments a. The test fails if ReadMarkdown
+// returns an error.
+func testReadMarkdown(t *testing.T, s string, a *tstable.ReadMarkdownArgs) *tstable.Table {
+	// Read table from s
+	tbl, e := tstable.ReadMarkdown(strings.NewReader(s), a)
+	// The test fails if ReadMarkdown returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ReadMarkdown", Fn: "table", Err: e}))
+	}
+	// Return table
+	return tbl
+}
+
+// TestReadMarkdown tests reading the Markdown representation of the test table with aligned columns. The test fails
+// if the printed table does not equal to the contents of the test data golden file.
+func TestReadMarkdown(t *testing.T) {
+	// Retrieve Markdown representation of test table with aligned columns
+	s, e := testAlignedTable(t).Markdown()
+	// The test fails if Markdown returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Markdown", Fn: "table", Err: e}))
+	}
+	// Read the table embedded in text
+	tbl := testReadMarkdown(t, "# Fellowship\n\nMembers | of the fellowship\n\n"+s+"\nThe End | \n", nil)
+	// Set padding and sort by column like the test table
+	if e := tbl.SetPadding(padding); e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "SetPadding", Fn: "table", Err: e}))
+	}
+	if e := tbl.SortBy(sortby); e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "SortBy", Fn: "table", Err: e}))
+	}
+	// Evaluate the table
+	evalTable("Aligned", tbl, t)
+}
+
+// TestReadMarkdownCells tests reading a pipe table without leading and trailing pipes, with escaped pipes and with
+// ragged rows, which are padded and truncated per default. The test fails if the Markdown representation does not
+// match.
+func TestReadMarkdownCells(t *testing.T) {
+	// Read table with ragged rows
+	tbl := testReadMarkdown(t, "Ring | Bearer\n:-: | -\nOne \\| Ruling | Frodo \\|\nNarya\nVilya | Elrond | Rivendell\n", nil)
+	// Retrieve Markdown representation
+	s, e := tbl.Markdown()
+	// The test fails if Markdown returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Markdown", Fn: "table", Err: e}))
+	}
+	// The test fails if the Markdown representation does not match
+	want := "| Ring | Bearer |\n| :---: | --- |\n| Narya |  |\n| One \\| Ruling | Frodo \\| |\n| Vilya | Elrond |\n"
+	if s != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "ReadMarkdown", Actual: s, Want: want}))
+	}
+}
+
+// TestReadMarkdownEscape tests the round trip of cells with backslashes and escaped pipes through Markdown and
+// ReadMarkdown. The test fails if the Markdown representation does not match or if the cells are not read back.
+func TestReadMarkdownEscape(t *testing.T) {
+	// Retrieve new test table with backslashes and pipes
+	tbl, e := tstable.New([]string{"Pattern", "Path"})
+	// The test fails if New returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "New", Fn: "table", Err: e}))
+	}
+	if e := tbl.AddRow([]string{`x\|y`, `C:\dir\`}); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: "table", Err: e}))
+	}
+	// Retrieve Markdown representation
+	s, e := tbl.Markdown()
+	// The test fails if Markdown returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Markdown", Fn: "table", Err: e}))
+	}
+	// The test fails if backslashes and pipes are not escaped
+	want := "| Pattern | Path |\n| --- | --- |\n| x\\\\\\|y | C:\\\\dir\\\\ |\n"
+	if s != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "Markdown", Actual: s, Want: want}))
+	}
+	// The test fails if the cells are not read back
+	r, e := tstable.ToStructs[struct{ Pattern, Path string }](testReadMarkdown(t, s, nil))
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ToStructs", Fn: "table", Err: e}))
+	}
+	if (len(r) != 1) || (r[0].Pattern != `x\|y`) || (r[0].Path != `C:\dir\`) {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "cells", Actual: fmt.Sprint(r), Want: `[{x\|y C:\dir\}]`}))
+	}
+}
+
+// TestReadMarkdownErr tests ReadMarkdown with a nil reader, text without a pipe table, a header not matching the
+// alignment row, a ragged row with RaggedError, a non-printable rune and an unknown policy. The test fails if
+// ReadMarkdown does not return an error.
+func TestReadMarkdownErr(t *testing.T) {
+	strict := tstable.RaggedError
+	// The test fails if ReadMarkdown does not return an error for a nil reader
+	if _, e := tstable.ReadMarkdown(nil, nil); e == nil {
+		t.Error(tserr.NilFailed("ReadMarkdown"))
+	}
+	// Test cases with the Markdown text and the arguments
+	for _, c := range []struct {
+		s string
+		a *tstable.ReadMarkdownArgs
+	}{
+		{"", nil},
+		{"# Title\n\n---\n", nil},
+		{"| a | b |\n| --- |\n| 1 | 2 |\n", nil},
+		{"| a | b |\n| --- | --- |\n| 1 |\n", &tstable.ReadMarkdownArgs{Ragged: &strict}},
+		{"| a | b |\n| --- | --- |\n| 1\a | 2 |\n", nil},
+		{"| a | b |\n| --- | --- |\n", &tstable.ReadMarkdownArgs{NonPrintable: -1}},
+	} {
+		// The test fails if ReadMarkdown does not return an error
+		if _, e := tstable.ReadMarkdown(strings.NewReader(c.s), c.a); e == nil {
+			t.Error(tserr.NilFailed("ReadMarkdown"))
+		}
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable
+
+// Import Go standard packages and tserr
+import (
+	"bufio"        // bufio
+	"io"           // io
+	"regexp"       // regexp
+	"strings"      // strings
+	"unicode/utf8" // utf8
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// orgVert is the Org-mode entity for the cell separator | in cells
+const orgVert = `\vert{}`
+
+// orgCookie maps a column alignment to its Org-mode alignment cookie
+var orgCookie = map[Alignment]string{
+	AlignLeft:   "<l>",
+	AlignRight:  "<r>",
+	AlignCenter: "<c>",
+}
+
+// orgCookieRegexp matches an Org-mode alignment cookie with an optional alignment and width
+var orgCookieRegexp = regexp.MustCompile(`^<([lrc]?)[0-9]*>$`)
+
+// Org returns table t as an Org-mode table. The header is separated from the rows by a horizontal line.
+// If a column is not left-aligned, a row with alignment cookies is added below the header. The cell separator |
+// in cells is replaced by \vert{}. The rows are sorted in the same order as with Print. It returns an empty string
+// and an error, if any.
+func (t *Table) Org() (string, error) {
+	// Retrieve view of t
+	v, e := t.view()
+	// Return an empty string and an error if view fails
+	if e != nil {
+		return "", tserr.Op(&tserr.OpArgs{Op: "view", Fn: "table", Err: e})
+	}
+	// Return Org-mode representation
+	return v.org()
+}
+
+// org returns view v as an Org-mode table.
+func (v *View) org() (string, error) {
+	// Escape header and rows
+	header, rows := orgEscape(v.header), make([][]string, len(v.rows))
+	for i, r := range v.rows {
+		rows[i] = orgEscape(r)
+	}
+	// Retrieve alignment cookies, if any column is not left-aligned
+	var cookies []string
+	for i := range v.header {
+		if v.Alignment(i) != AlignLeft {
+			cookies = make([]string, len(v.header))
+			for j := range cookies {
+				cookies[j] = orgCookie[v.Alignment(j)]
+			}
+			break
+		}
+	}
+	// Compute column widths of escaped cells with a minimum width of one
+	w := make([]int, len(header))
+	for _, r := range append([][]string{header, cookies}, rows...) {
+		for i, c := range r {
+			w[i] = max(w[i], utf8.RuneCountInString(c), 1)
+		}
+	}
+	var b strings.Builder
+	// Write header and horizontal line
+	v.orgRow(&b, w, header)
+	s := make([]string, len(w))
+	for i, v := range w {
+		s[i] = strings.Repeat("-", v+2)
+	}
+	b.WriteString("|" + strings.Join(s, "+") + "|\n")
+	// Write alignment cookies
+	if cookies != nil {
+		v.orgRow(&b, w, cookies)
+	}
+	// Write rows
+	for _, r := range rows {
+		v.orgRow(&b, w, r)
+	}
+	// Return Org-mode representation
+	return b.String(), nil
+}
+
+// orgEscape returns a copy of row r with the cell separator | replaced by \vert{}.
+func orgEscape(r []string) []string {
+	e := make([]string, len(r))
+	for i, c := range r {
+		e[i] = strings.ReplaceAll(c, "|", orgVert)
+	}
+	return e
+}
+
+// orgRow writes row r padded to widths w according to the column alignment to b.
+func (v *View) orgRow(b *strings.Builder, w []int, r []string) {
+	s := make([]string, len(r))
+	for i, c := range r {
+		s[i] = pad(c, w[i], v.Alignment(i))
+	}
+	b.WriteString("| " + strings.Join(s, " | ") + " |\n")
+}
+
+// ReadOrg returns a new Table read from the first Org-mode table in r. Lines before the table are skipped and
+// the table ends with the first line which is not a table line. Rows above the first horizontal line are joined
+// to the header. If the table has no horizontal line, the first row is the header. A row with alignment
+// cookies like <l>, <r> or <c> directly below the header or a horizontal line sets the column alignment. Other
+// rows with alignment cookies are rows of the table. Missing cells are filled with empty strings and
+// \vert{} is replaced by |. It returns nil and an error, if r does not contain an Org-mode table or if
+// a cell contains non-printable runes.
+func ReadOrg(r io.Reader) (*Table, error) {
+	// Return nil and an error if r is nil
+	if r == nil {
+		return nil, tserr.NilPtr()
+	}
+	var (
+		rows   [][]string  // Rows of the table
+		align  []Alignment // Column alignment
+		hline  = -1        // Number of rows above the first horizontal line
+		n      int         // Number of columns
+		inside bool        // Scanner is inside the table
+		cookie bool        // Previous line is the header or a horizontal line
+	)
+	// Scan r line by line
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		l := strings.TrimSpace(s.Text())
+		// Skip lines before the table and stop at the end of the table
+		if !strings.HasPrefix(l, "|") {
+			if inside {
+				break
+			}
+			continue
+		}
+		inside = true
+		// Remember the position of the first horizontal line
+		if strings.HasPrefix(l, "|-") {
+			if hline < 0 {
+				hline = len(rows)
+			}
+			cookie = true
+			continue
+		}
+		// Split line into cells
+		c := orgCells(l)
+		n = max(n, len(c))
+		// Retrieve alignment from a row with alignment cookies directly below the header or a horizontal line
+		if a, ok := orgAlignment(c); ok && cookie {
+			align, cookie = a, false
+			continue
+		}
+		rows = append(rows, c)
+		// The next line may contain alignment cookies, if this row is the header
+		cookie = (hline < 0) && (len(rows) == 1)
+	}
+	// Return nil and an error, if scanning fails
+	if e := s.Err(); e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "Scan", Fn: "Org-mode table", Err: e})
+	}
+	// Return nil and an error, if no table row was found
+	if len(rows) == 0 {
+		return nil, tserr.Empty("Org-mode table")
+	}
+	// Fill missing cells
+	for i := range rows {
+		rows[i] = append(rows[i], make([]string, n-len(rows[i]))...)
+	}
+	// The first row is the header, if there is no horizontal line or no row above it
+	if hline < 1 {
+		hline = 1
+	}
+	// Join rows above the first horizontal line to the header
+	header := make([]string, n)
+	for i := range header {
+		p := make([]string, 0, hline)
+		for _, h := range rows[:hline] {
+			if h[i] != "" {
+				p = append(p, h[i])
+			}
+		}
+		header[i] = strings.Join(p, " ")
+	}
+	// Retrieve new table with header
+	t, e := New(header)
+	if e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "New", Fn: "Org-mode table", Err: e})
+	}
+	// Add rows below the header
+	for _, r := range rows[hline:] {
+		if e := t.AddRow(r); e != nil {
+			return nil, tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: "Org-mode table", Err: e})
+		}
+	}
+	// Set column alignment from alignment cookies
+	copy(t.align, align)
+	// Return table
+	return t, nil
+}
+
+// orgCells returns the trimmed cells of Org-mode table line l with \vert{} replaced by |.
+func orgCells(l string) []string {
+	// Remove leading and optional trailing cell separator
+	l = strings.TrimPrefix(l, "|")
+	l = strings.TrimSuffix(l, "|")
+	// Split line into cells
+	c := strings.Split(l, "|")
+	for i := range c {
+		c[i] = strings.ReplaceAll(strings.TrimSpace(c[i]), orgVert, "|")
+	}
+	// Return cells
+	return c
+}
+
+// orgAlignment returns the column alignment, if cells c only contain alignment cookies or empty cells.
+// The second return value is false, if c is not a row with alignment cookies.
+func orgAlignment(c []string) ([]Alignment, bool) {
+	var (
+		a     = make([]Alignment, len(c)) // Column alignment
+		found bool                        // At least one alignment cookie was found
+	)
+	// Iterate all cells
+	for i, v := range c {
+		// Skip empty cells
+		if v == "" {
+			continue
+		}
+		// Return false, if cell is not an alignment cookie
+		m := orgCookieRegexp.FindStringSubmatch(v)
+		if m == nil {
+			return nil, false
+		}
+		found = true
+		// Set alignment
+		switch m[1] {
+		case "r":
+			a[i] = AlignRight
+		case "c":
+			a[i] = AlignCenter
+		}
+	}
+	// Return alignment and whether the row contains alignment cookies
+	return a, found
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable_test
+
+// Import Go standard library packages as well as tstable and tserr
+import (
+	"strings" // strings
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tstable" // tstable
+)
+
+// TestOrg tests the Org-mode representation of the test table with a right-aligned and a centered column.
+// The test fails if the Org-mode representation does not equal to the contents of the test data golden file.
+func TestOrg(t *testing.T) {
+	// Retrieve Org-mode representation of test table with aligned columns
+	s, e := testAlignedTable(t).Org()
+	// The test fails if Org returns an error
+	if e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "Org", Fn: "table", Err: e}))
+	}
+	// Evaluate the Org-mode representation
+	evalGolden("Org", s, t)
+}
+
+// TestOrgRoundTrip tests reading the Org-mode representation of the test table. The test fails if
+// the Org-mode representation of the read table differs from the original Org-mode representation.
+func TestOrgRoundTrip(t *testing.T) {
+	// Retrieve Org-mode representation of test table with aligned columns
+	s, e := testAlignedTable(t).Org()
+	// The test fails if Org returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Org", Fn: "table", Err: e}))
+	}
+	// Read the Org-mode representation
+	tbl, e := tstable.ReadOrg(strings.NewReader("#+NAME: fellowship\n" + s + "\nText after the table\n"))
+	// The test fails if ReadOrg returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ReadOrg", Fn: "table", Err: e}))
+	}
+	// Set sort by column of the test table
+	if e := tbl.SortBy(sortby); e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "SortBy", Fn: sortby, Err: e}))
+	}
+	// Retrieve Org-mode representation of the read table
+	r, e := tbl.Org()
+	// The test fails if Org returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Org", Fn: "table", Err: e}))
+	}
+	// The test fails if the Org-mode representations differ
+	if r != s {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "Org", Actual: r, Want: s}))
+	}
+}
+
+// TestReadOrg tests reading an Org-mode table with a two-line header, missing cells and an escaped cell
+// separator. The test fails if the JSON representation of the read table does not match.
+func TestReadOrg(t *testing.T) {
+	// Org-mode table
+	org := "| Name | Size |\n| | in GB |\n|---+---|\n| a\\vert{}b |\n| c | 2 |\n"
+	// Read the Org-mode table
+	tbl, e := tstable.ReadOrg(strings.NewReader(org))
+	// The test fails if ReadOrg returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ReadOrg", Fn: "table", Err: e}))
+	}
+	// Retrieve JSON representation
+	s, e := tbl.JSON(&tstable.JSONArgs{Layout: tstable.JSONArrays})
+	// The test fails if JSON returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "JSON", Fn: "table", Err: e}))
+	}
+	// The test fails if the JSON representation does not match
+	want := `{"header":["Name","Size in GB"],"rows":[["a|b",""],["c","2"]]}`
+	if s != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "JSON", Actual: s, Want: want}))
+	}
+}
+
+// TestReadOrgCookies tests reading an Org-mode table with alignment cookies below the horizontal line and a row
+// of cells looking like alignment cookies. The test fails if the Org-mode representation of the read table does
+// not keep the row.
+func TestReadOrgCookies(t *testing.T) {
+	// Org-mode table with a row of cells looking like alignment cookies
+	org := "| Tag | Width |\n|-----+-------|\n| <l> |   <r> |\n| <l> |  <10> |\n| x   |     1 |\n"
+	// Read the Org-mode table
+	tbl, e := tstable.ReadOrg(strings.NewReader(org))
+	// The test fails if ReadOrg returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ReadOrg", Fn: "table", Err: e}))
+	}
+	// Retrieve Org-mode representation
+	s, e := tbl.Org()
+	// The test fails if Org returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Org", Fn: "table", Err: e}))
+	}
+	// The test fails if the Org-mode representation does not match
+	if s != org {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "Org", Actual: s, Want: org}))
+	}
+}
+
+// TestReadOrgErr tests ReadOrg to return an error in case the input does not contain an Org-mode table.
+// The test fails if ReadOrg returns a nil error.
+func TestReadOrgErr(t *testing.T) {
+	// The test fails if ReadOrg returns a nil error
+	if _, e := tstable.ReadOrg(strings.NewReader("no table\n")); e == nil {
+		t.Error(tserr.NilFailed("ReadOrg"))
+	}
+}
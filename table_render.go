@@ -0,0 +1,275 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable
+
+// Import Go standard packages and tserr
+import (
+	"io"   // io
+	"sort" // sort
+	"sync" // sync
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// A View is a read-only view of a table for rendering. The rows of a View are sorted in the same order as with Print.
+// A View is only valid during the call of Render.
+type View struct {
+	header  []string    // Header as a slice of strings
+	rows    [][]string  // Sorted rows as a slice of slices of strings
+	width   []int       // Width of each column
+	align   []Alignment // Alignment of each column
+	padding int         // Padding
+	grid    Grid        // Table grid
+}
+
+// A Renderer renders a View of a table to an io.Writer. Renderers can be registered with Register
+// for a format name to be used with Render.
+type Renderer interface {
+	Render(w io.Writer, v *View) error
+}
+
+// The RendererFunc type is an adapter to allow the use of ordinary functions as a Renderer.
+type RendererFunc func(w io.Writer, v *View) error
+
+// Render calls f(w, v).
+func (f RendererFunc) Render(w io.Writer, v *View) error {
+	return f(w, v)
+}
+
+// Registry of renderers by format name
+var (
+	renderersMu sync.RWMutex // Lock for renderers
+	renderers   = map[string]Renderer{
+		"box":       RendererFunc(func(w io.Writer, v *View) error { _, e := v.boxTo(w); return e }),
+		"json":      stringRenderer(func(v *View) (string, error) { return v.json(nil) }),
+		"latex":     stringRenderer(func(v *View) (string, error) { return v.latex(nil) }),
+		"rst":       stringRenderer(func(v *View) (string, error) { return v.rst(nil) }),
+		"asciidoc":  stringRenderer((*View).asciidoc),
+		"jira":      stringRenderer((*View).jira),
+		"mediawiki": stringRenderer((*View).mediawiki),
+		"org":       stringRenderer((*View).org),
+		"svg":       stringRenderer(func(v *View) (string, error) { return v.svg(nil) }),
+		"tbl":       stringRenderer(func(v *View) (string, error) { return v.tbl(nil) }),
+		"sql":       stringRenderer(func(v *View) (string, error) { return v.sql(nil) }),
+		"markdown":  stringRenderer((*View).markdown),
+		"csv":       RendererFunc(func(w io.Writer, v *View) error { return v.csv(w, ',') }),
+		"tsv":       RendererFunc(func(w io.Writer, v *View) error { return v.csv(w, '\t') }),
+		"xlsx":      RendererFunc(func(w io.Writer, v *View) error { return v.xlsx(w, nil) }),
+	}
+)
+
+// Register registers renderer r for format name. It returns an error if name is empty, if r is nil or if a renderer is
+// already registered for name.
+func Register(name string, r Renderer) error {
+	// Return an error if name is empty
+	if name == "" {
+		return tserr.Empty("format name")
+	}
+	// Return an error if r is nil
+	if r == nil {
+		return tserr.NilPtr()
+	}
+	// Lock renderers
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+	// Return an error if a renderer is already registered for name
+	if _, ok := renderers[name]; ok {
+		return tserr.Duplicate("format " + name)
+	}
+	// Register r for name
+	renderers[name] = r
+	// Return nil
+	return nil
+}
+
+// Unregister removes the renderer registered for format name. It returns an error if no renderer is registered
+// for name.
+func Unregister(name string) error {
+	// Lock renderers
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+	// Return an error if no renderer is registered for name
+	if _, ok := renderers[name]; !ok {
+		return tserr.NotExistent("format " + name)
+	}
+	// Remove the renderer for name
+	delete(renderers, name)
+	// Return nil
+	return nil
+}
+
+// Formats returns the sorted names of all registered formats.
+func Formats() []string {
+	// Lock renderers for reading
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+	// Retrieve names of all formats
+	f := make([]string, 0, len(renderers))
+	for n := range renderers {
+		f = append(f, n)
+	}
+	// Return sorted names
+	sort.Strings(f)
+	return f
+}
+
+// Render renders table t in format with the renderer registered for format and writes it to w. The renderers of the
+// package use their default arguments. It returns an error if no renderer is registered for format or if rendering fails.
+func (t *Table) Render(w io.Writer, format string) error {
+	// Return an error if t or w is nil
+	if (t == nil) || (w == nil) {
+		return tserr.NilPtr()
+	}
+	// Retrieve renderer for format
+	renderersMu.RLock()
+	r, ok := renderers[format]
+	renderersMu.RUnlock()
+	// Return an error if no renderer is registered for format
+	if !ok {
+		return tserr.NotExistent("format " + format)
+	}
+	// Retrieve view of t
+	v, e := t.view()
+	if e != nil {
+		return tserr.Op(&tserr.OpArgs{Op: "view", Fn: "table", Err: e})
+	}
+	// Render view
+	if e := r.Render(w, v); e != nil {
+		return tserr.Op(&tserr.OpArgs{Op: "Render", Fn: format, Err: e})
+	}
+	// Return nil
+	return nil
+}
+
+// stringRenderer returns a Renderer writing the string returned by f to w.
+func stringRenderer(f func(*View) (string, error)) Renderer {
+	return RendererFunc(func(w io.Writer, v *View) error {
+		// Retrieve string representation
+		s, e := f(v)
+		if e != nil {
+			return e
+		}
+		// Write string representation to w
+		if _, e := io.WriteString(w, s); e != nil {
+			return tserr.Op(&tserr.OpArgs{Op: "WriteString", Fn: "view", Err: e})
+		}
+		// Return nil
+		return nil
+	})
+}
+
+// view returns a View of table t with rows sorted in the same order as with Print. It returns nil and an error,
+// if t is inconsistent or if sorting fails.
+func (t *Table) view() (*View, error) {
+	// Return nil and an error if t is nil
+	if t == nil {
+		return nil, tserr.NilPtr()
+	}
+	// Return nil and an error, if header, rows or grid are nil
+	if (t.header == nil) || (t.rows == nil) || (t.grid == nil) {
+		return nil, tserr.NilPtr()
+	}
+	// Return nil and an error, if the number of elements in header does not equal the number of elements in width
+	if len(t.header) != len(t.width) {
+		return nil, tserr.Equal(&tserr.EqualArgs{Var: "table width slice", Actual: int64(len(t.width)), Want: int64(len(t.header))})
+	}
+	// Return nil and an error, if the number of elements in header does not equal the number of elements in align
+	if len(t.header) != len(t.align) {
+		return nil, tserr.Equal(&tserr.EqualArgs{Var: "table alignment slice", Actual: int64(len(t.align)), Want: int64(len(t.header))})
+	}
+	// Retrieve rows sorted by the sort keys without modifying the rows of t
+	rows, e := t.sorted()
+	if e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "sorted", Fn: "table", Err: e})
+	}
+	// Return view of t
+	return &View{
+		header:  t.header,
+		rows:    rows,
+		width:   t.width,
+		align:   t.align,
+		padding: t.padding,
+		grid:    *t.grid,
+	}, nil
+}
+
+// Columns returns the number of columns of view v.
+func (v *View) Columns() int {
+	// Return zero if v is nil
+	if v == nil {
+		return 0
+	}
+	// Return number of columns
+	return len(v.header)
+}
+
+// Len returns the number of rows of view v.
+func (v *View) Len() int {
+	// Return zero if v is nil
+	if v == nil {
+		return 0
+	}
+	// Return number of rows
+	return len(v.rows)
+}
+
+// Header returns a copy of the header of view v.
+func (v *View) Header() []string {
+	// Return nil if v is nil
+	if v == nil {
+		return nil
+	}
+	// Return copy of header
+	return append([]string(nil), v.header...)
+}
+
+// Row returns a copy of the row with index i of view v. It returns nil, if i is out of range.
+func (v *View) Row(i int) []string {
+	// Return nil if v is nil or i is out of range
+	if (v == nil) || (i < 0) || (i >= len(v.rows)) {
+		return nil
+	}
+	// Return copy of row i
+	return append([]string(nil), v.rows[i]...)
+}
+
+// Width returns the width of column c of view v in runes. It returns zero, if c is out of range.
+func (v *View) Width(c int) int {
+	// Return zero if v is nil or c is out of range
+	if (v == nil) || (c < 0) || (c >= len(v.width)) {
+		return 0
+	}
+	// Return width of column c
+	return v.width[c]
+}
+
+// Alignment returns the alignment of column c of view v. It returns AlignLeft, if c is out of range.
+func (v *View) Alignment(c int) Alignment {
+	// Return AlignLeft if v is nil or c is out of range
+	if (v == nil) || (c < 0) || (c >= len(v.align)) {
+		return AlignLeft
+	}
+	// Return alignment of column c
+	return v.align[c]
+}
+
+// Padding returns the padding of view v.
+func (v *View) Padding() int {
+	// Return zero if v is nil
+	if v == nil {
+		return 0
+	}
+	// Return padding
+	return v.padding
+}
+
+// Grid returns a copy of the grid of view v.
+func (v *View) Grid() Grid {
+	// Return the EmptyGrid if v is nil
+	if v == nil {
+		return EmptyGrid
+	}
+	// Return grid
+	return v.grid
+}
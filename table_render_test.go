@@ -0,0 +1,192 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable_test
+
+// Import Go standard library packages as well as tstable and tserr
+import (
+	"bytes"   // bytes
+	"errors"  // errors
+	"io"      // io
+	"slices"  // slices
+	"strings" // strings
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tstable" // tstable
+)
+
+// TestFormats tests that the formats of the package are registered. The test fails if a format is missing
+// or if the formats are not sorted.
+func TestFormats(t *testing.T) {
+	// Retrieve registered formats
+	f := tstable.Formats()
+	// The test fails if a format of the package is missing
+	for _, n := range []string{"box", "markdown", "csv", "tsv", "json", "latex", "rst", "asciidoc", "jira", "mediawiki", "org", "svg", "tbl", "sql", "xlsx"} {
+		if !slices.Contains(f, n) {
+			t.Error(tserr.NotExistent("format " + n))
+		}
+	}
+	// The test fails if the formats are not sorted
+	if !slices.IsSorted(f) {
+		t.Error(tserr.Forbidden("unsorted formats"))
+	}
+}
+
+// TestRenderBox tests that rendering the test table in format box equals Print. The test fails if Render
+// returns an error or if the rendered table does not equal the string returned by Print.
+func TestRenderBox(t *testing.T) {
+	// Retrieve test table
+	tbl := testTable(t)
+	// Render test table in format box
+	var b bytes.Buffer
+	if e := tbl.Render(&b, "box"); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Render", Fn: "box", Err: e}))
+	}
+	// Retrieve string representation of test table
+	s, e := tbl.Print()
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Print", Fn: "table", Err: e}))
+	}
+	// The test fails if the rendered table does not equal the string representation
+	if b.String() != s {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "box", Actual: b.String(), Want: s}))
+	}
+}
+
+// TestRenderFormats tests rendering the test table in all registered formats. The test fails if Render returns
+// an error or if the rendered table is empty.
+func TestRenderFormats(t *testing.T) {
+	// Retrieve test table
+	tbl := testTable(t)
+	// Iterate registered formats
+	for _, f := range tstable.Formats() {
+		// Render test table in format f
+		var b bytes.Buffer
+		if e := tbl.Render(&b, f); e != nil {
+			t.Error(tserr.Op(&tserr.OpArgs{Op: "Render", Fn: f, Err: e}))
+		}
+		// The test fails if the rendered table is empty
+		if b.Len() == 0 {
+			t.Error(tserr.Empty(f))
+		}
+	}
+}
+
+// TestRenderErr tests Render with an unknown format, a nil writer and a nil table. The test fails if
+// Render does not return an error.
+func TestRenderErr(t *testing.T) {
+	// Retrieve test table
+	tbl := testTable(t)
+	var b bytes.Buffer
+	// The test fails if Render does not return an error for an unknown format
+	if e := tbl.Render(&b, "unknown"); e == nil {
+		t.Error(tserr.NilFailed("Render"))
+	}
+	// The test fails if Render does not return an error for a nil writer
+	if e := tbl.Render(nil, "box"); e == nil {
+		t.Error(tserr.NilFailed("Render"))
+	}
+	// The test fails if Render does not return an error for a nil table
+	var n *tstable.Table
+	if e := n.Render(&b, "box"); e == nil {
+		t.Error(tserr.NilFailed("Render"))
+	}
+}
+
+// testRegister registers renderer r for format name and unregisters it when test t and its subtests complete.
+// The test fails if r cannot be registered or unregistered.
+func testRegister(t *testing.T, name string, r tstable.Renderer) {
+	// Register r for name
+	if e := tstable.Register(name, r); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Register", Fn: name, Err: e}))
+	}
+	// Unregister r at the end of the test
+	t.Cleanup(func() {
+		if e := tstable.Unregister(name); e != nil {
+			t.Error(tserr.Op(&tserr.OpArgs{Op: "Unregister", Fn: name, Err: e}))
+		}
+	})
+}
+
+// TestRegister tests a custom renderer implemented outside of the package. The renderer writes the header and the
+// first cell of each row separated by semicolons. The test fails if the renderer cannot be registered or if the
+// rendered table does not match.
+func TestRegister(t *testing.T) {
+	// Register custom renderer
+	r := tstable.RendererFunc(func(w io.Writer, v *tstable.View) error {
+		c := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c = append(c, v.Row(i)[0])
+		}
+		_, e := io.WriteString(w, strings.Join(v.Header(), ";")+"\n"+strings.Join(c, ";")+"\n")
+		return e
+	})
+	testRegister(t, "test", r)
+	// Render test table with the custom renderer
+	var b bytes.Buffer
+	if e := testTable(t).Render(&b, "test"); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Render", Fn: "test", Err: e}))
+	}
+	// The test fails if the rendered table does not match. The rows are sorted by column Weapon.
+	want := "Fellowship member;Title;Weapon\nGimli;Legolas;Aragorn;Boromir;Gandalf\n"
+	if b.String() != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "test", Actual: b.String(), Want: want}))
+	}
+}
+
+// TestRegisterErr tests Register with an empty name, a nil renderer and a name which is already registered as well as
+// Unregister with a name which is not registered. The test fails if Register or Unregister does not return an error.
+func TestRegisterErr(t *testing.T) {
+	r := tstable.RendererFunc(func(w io.Writer, v *tstable.View) error { return nil })
+	// The test fails if Register does not return an error for an empty name
+	if e := tstable.Register("", r); e == nil {
+		t.Error(tserr.NilFailed("Register"))
+	}
+	// The test fails if Register does not return an error for a nil renderer
+	if e := tstable.Register("nil", nil); e == nil {
+		t.Error(tserr.NilFailed("Register"))
+	}
+	// The test fails if Register does not return an error for a registered name
+	if e := tstable.Register("box", r); e == nil {
+		t.Error(tserr.NilFailed("Register"))
+	}
+	// The test fails if Unregister does not return an error for a name which is not registered
+	if e := tstable.Unregister("unknown"); e == nil {
+		t.Error(tserr.NilFailed("Unregister"))
+	}
+}
+
+// TestView tests the accessors of a View. The test fails if an accessor returns an unexpected value.
+func TestView(t *testing.T) {
+	// Render aligned test table with a renderer checking the view. The renderer returns all failed checks as an error.
+	r := tstable.RendererFunc(func(w io.Writer, v *tstable.View) error {
+		var f []error
+		if (v.Columns() != 3) || (v.Len() != 5) {
+			f = append(f, tserr.Equal(&tserr.EqualArgs{Var: "rows", Actual: int64(v.Len()), Want: 5}))
+		}
+		if v.Width(1) != len(legolas[1]) {
+			f = append(f, tserr.Equal(&tserr.EqualArgs{Var: "width", Actual: int64(v.Width(1)), Want: int64(len(legolas[1]))}))
+		}
+		if (v.Alignment(1) != tstable.AlignRight) || (v.Alignment(2) != tstable.AlignCenter) || (v.Alignment(3) != tstable.AlignLeft) {
+			f = append(f, tserr.Forbidden("alignment"))
+		}
+		if (v.Padding() != padding) || (v.Grid() != tstable.SimpleGrid) {
+			f = append(f, tserr.Forbidden("padding or grid"))
+		}
+		if (v.Row(-1) != nil) || (v.Row(5) != nil) || (v.Width(3) != 0) {
+			f = append(f, tserr.Forbidden("out of range"))
+		}
+		// Modifying a copy of a row must not change the view
+		v.Row(0)[0] = ""
+		if v.Row(0)[0] == "" {
+			f = append(f, tserr.Forbidden("modified view"))
+		}
+		return errors.Join(f...)
+	})
+	testRegister(t, "view", r)
+	// The test fails if Render returns an error for a failed check
+	if e := testAlignedTable(t).Render(io.Discard, "view"); e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "Render", Fn: "view", Err: e}))
+	}
+}
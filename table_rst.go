@@ -0,0 +1,139 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable
+
+// Import Go standard package strings and tserr
+import (
+	"strings" // strings
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// RSTArgs holds the arguments for the reStructuredText representation of a table.
+//
+//	Simple:	return a simple table instead of a grid table
+type RSTArgs struct {
+	Simple bool // Simple table
+}
+
+// RST returns table t as a reStructuredText grid table. If Simple is set in a, it returns table t as
+// a reStructuredText simple table. In a grid table, each row is separated by a grid line and the header is
+// separated by a grid line of =. Cells are padded according to the column alignment. Cells are not escaped
+// and may contain inline markup. The rows are sorted in the same order as with Print. It returns an empty string
+// and an error, if any.
+func (t *Table) RST(a *RSTArgs) (string, error) {
+	// Retrieve view of t
+	v, e := t.view()
+	// Return an empty string and an error if view fails
+	if e != nil {
+		return "", tserr.Op(&tserr.OpArgs{Op: "view", Fn: "table", Err: e})
+	}
+	// Return reStructuredText representation
+	return v.rst(a)
+}
+
+// rst returns view v as a reStructuredText grid table or simple table.
+func (v *View) rst(a *RSTArgs) (string, error) {
+	// Use default arguments, if a is nil
+	if a == nil {
+		a = &RSTArgs{}
+	}
+	var b strings.Builder
+	// Write simple or grid table
+	if a.Simple {
+		v.rstSimple(&b)
+	} else {
+		v.rstGrid(&b)
+	}
+	// Return reStructuredText representation
+	return b.String(), nil
+}
+
+// rstGrid writes view v as a reStructuredText grid view vo b.
+func (v *View) rstGrid(b *strings.Builder) {
+	// Retrieve column widths
+	w := v.rstWidths(false)
+	// Grid line between rows and grid line below the header
+	line, hline := rstLine(w, '-', 1, "+", "+"), rstLine(w, '=', 1, "+", "+")
+	// Write header
+	b.WriteString(line)
+	v.rstRow(b, w, v.header, "| ", " | ", " |")
+	b.WriteString(hline)
+	// Write rows, each followed by a grid line
+	for _, r := range v.rows {
+		v.rstRow(b, w, r, "| ", " | ", " |")
+		b.WriteString(line)
+	}
+}
+
+// rstSimple writes view v as a reStructuredText simple view vo b.
+func (v *View) rstSimple(b *strings.Builder) {
+	// Retrieve column widths
+	w := v.rstWidths(true)
+	// Column borders
+	line := rstLine(w, '=', 0, "", "  ")
+	// Write header
+	b.WriteString(line)
+	v.rstRow(b, w, v.header, "", "  ", "")
+	b.WriteString(line)
+	// Write rows
+	for _, r := range v.rows {
+		// An empty first column would continue the previous row. It is replaced by an escaped space.
+		if (len(r) > 0) && (r[0] == "") {
+			r = append([]string{rstEmpty}, r[1:]...)
+		}
+		v.rstRow(b, w, r, "", "  ", "")
+	}
+	b.WriteString(line)
+}
+
+// rstEmpty is an escaped space, which represents an empty first column of a row in a simple table
+const rstEmpty = `\ `
+
+// rstWidths returns the column widths of view v with a minimum width of one. For simple tables, the
+// width of the first column fits rstEmpty, if a row has an empty first column.
+func (v *View) rstWidths(simple bool) []int {
+	// Copy widths with a minimum width of one
+	w := make([]int, len(v.width))
+	for i, v := range v.width {
+		w[i] = max(v, 1)
+	}
+	// Return widths of a grid table or an empty simple table
+	if !simple || (len(w) == 0) {
+		return w
+	}
+	// Fit rstEmpty into the first column, if any row has an empty first column
+	for _, r := range v.rows {
+		if (len(r) > 0) && (r[0] == "") {
+			w[0] = max(w[0], len(rstEmpty))
+			break
+		}
+	}
+	// Return widths
+	return w
+}
+
+// rstLine returns a horizontal line of rune h for columns with widths w and padding p. The line starts and
+// ends with e and columns are separated by sep.
+func rstLine(w []int, h rune, p int, e, sep string) string {
+	// Add a line segment for each column
+	s := make([]string, len(w))
+	for i, v := range w {
+		s[i] = strings.Repeat(string(h), v+p+p)
+	}
+	// Return the line
+	return e + strings.Join(s, sep) + e + "\n"
+}
+
+// rstRow writes row r padded to widths w according to the column alignment and separated by sep to b. The row starts
+// with l and ends with e.
+func (v *View) rstRow(b *strings.Builder, w []int, r []string, l, sep, e string) {
+	// Pad each cell
+	s := make([]string, len(r))
+	for i, c := range r {
+		s[i] = pad(c, w[i], v.Alignment(i))
+	}
+	// Write row
+	b.WriteString(l + strings.Join(s, sep) + e + "\n")
+}
@@ -0,0 +1,61 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable_test
+
+// Import Go standard library package testing as well as tstable and tserr
+import (
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tstable" // tstable
+)
+
+// TestRST tests the reStructuredText representations of the test table with a right-aligned and a centered column.
+// The test fails if a reStructuredText representation does not equal to the contents of the test data golden file.
+func TestRST(t *testing.T) {
+	// Test cases with golden file names and reStructuredText arguments
+	tc := map[string]*tstable.RSTArgs{
+		"RSTGrid":   nil,
+		"RSTSimple": {Simple: true},
+	}
+	// Retrieve test table with aligned columns
+	tbl := testAlignedTable(t)
+	// Iterate all test cases
+	for name, a := range tc {
+		// Retrieve reStructuredText representation
+		s, e := tbl.RST(a)
+		// The test fails if RST returns an error
+		if e != nil {
+			t.Error(tserr.Op(&tserr.OpArgs{Op: "RST", Fn: name, Err: e}))
+		}
+		// Evaluate the reStructuredText representation
+		evalGolden(name, s, t)
+	}
+}
+
+// TestRSTSimpleEmpty tests the reStructuredText simple table with an empty first column. The test fails
+// if the empty cell is not replaced by an escaped space.
+func TestRSTSimpleEmpty(t *testing.T) {
+	// Retrieve new test table
+	tbl, e := tstable.New([]string{"a", "b"})
+	// The test fails if New returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "New", Fn: "table", Err: e}))
+	}
+	// Add a row with an empty first column
+	if e := tbl.AddRow([]string{"", "1"}); e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: "table", Err: e}))
+	}
+	// Retrieve reStructuredText simple table
+	s, e := tbl.RST(&tstable.RSTArgs{Simple: true})
+	// The test fails if RST returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "RST", Fn: "table", Err: e}))
+	}
+	// The test fails if the simple table does not match
+	want := "==  =\na   b\n==  =\n\\   1\n==  =\n"
+	if s != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "RST", Actual: s, Want: want}))
+	}
+}
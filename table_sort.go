@@ -5,69 +5,488 @@ package tstable
 
 // Import Go standard packages and tserr
 import (
-	"sort" // sort
+	"cmp"          // cmp
+	"math"         // math
+	"net/netip"    // netip
+	"slices"       // slices
+	"strconv"      // strconv
+	"strings"      // strings
+	"time"         // time
+	"unicode"      // unicode
+	"unicode/utf8" // utf8
 
 	"github.com/thorstenrie/tserr" // tserr
 )
 
-// byRow implements sort.Interface fo Table based on
-// the selected row, which is given by the row index in field key. Initially,
-// the first row is selected. The selected row can be changed with function SortBy.
-type byRow Table
+// A SortType defines the order of the cells of a column when sorting.
+type SortType int
 
-// Len returns the number of rows in Table
-func (t byRow) Len() int {
-	// Return zero in case rows is nil
-	if t.rows == nil {
-		return 0
-	}
-	// Otherwise, return the length of rows
-	return len(t.rows)
+const (
+	SortString   SortType = iota // Lexical order of strings (default)
+	SortInt                      // Integers, e.g., -3 or 42
+	SortFloat                    // Floating-point numbers, e.g., 1.5 or 2e3
+	SortTime                     // Times with the layout of SortArgs (default time.RFC3339)
+	SortDuration                 // Durations, e.g., 250ms or 1h30m
+	SortSize                     // Byte sizes, e.g., 200MB, 1.5GiB or 4K
+	SortVersion                  // Semantic versions, e.g., v1.10.0 or 2.0.0-rc.1
+	SortIP                       // IPv4 and IPv6 addresses, IPv4 before IPv6
+	SortNatural                  // Natural order of strings with numbers, e.g., node2 before node10
+)
+
+// An Unparsable policy defines the order of cells, which cannot be parsed according to the sort type of the column,
+// for example empty cells or n/a in a column of integers.
+type Unparsable int
+
+const (
+	UnparsableLast  Unparsable = iota // Sort unparsable cells after all other cells in lexical order (default)
+	UnparsableFirst                   // Sort unparsable cells before all other cells in lexical order
+	UnparsableError                   // Return an error when sorting
+)
+
+// SortArgs holds the sort type of a column. Empty fields are set to default values.
+//
+//	Type:		sort type of the column (default SortString)
+//	Layout:		layout of times for SortTime (default time.RFC3339)
+//	Unparsable:	order of cells, which cannot be parsed (default UnparsableLast)
+//	IgnoreCase:	compare strings case-insensitively for SortString and SortNatural (default false)
+type SortArgs struct {
+	Type       SortType   // Sort type
+	Layout     string     // Layout of times
+	Unparsable Unparsable // Policy for unparsable cells
+	IgnoreCase bool       // Case-insensitive strings
 }
 
-// Swap swaps the rows with indexes i and j.
-func (t byRow) Swap(i, j int) {
-	// Return without swap if rows is nil
-	if t.rows == nil {
-		return
+// sortUnits maps the units of byte sizes in lower case to their number of bytes. Units with a single letter
+// are binary multiples like in the output of df -h or ls -lh.
+var sortUnits = map[string]float64{
+	"": 1, "b": 1,
+	"k": 1 << 10, "kb": 1e3, "kib": 1 << 10,
+	"m": 1 << 20, "mb": 1e6, "mib": 1 << 20,
+	"g": 1 << 30, "gb": 1e9, "gib": 1 << 30,
+	"t": 1 << 40, "tb": 1e12, "tib": 1 << 40,
+	"p": 1 << 50, "pb": 1e15, "pib": 1 << 50,
+	"e": 1 << 60, "eb": 1e18, "eib": 1 << 60,
+}
+
+// SetSortType sets the sort type of column with header h to a. Per default, all columns are sorted in lexical order.
+// The sort types of the columns selected with SortBy or SortByKeys define the order of the rows. If a is nil, default
+// values are used. It returns an error if column header h cannot be found in table t or if the sort type or the
+// policy is not valid.
+func (t *Table) SetSortType(h string, a *SortArgs) error {
+	// Return an error if t is nil
+	if t == nil {
+		return tserr.NilPtr()
+	}
+	// Use default arguments, if a is nil
+	if a == nil {
+		a = &SortArgs{}
+	}
+	// Return an error if the sort type is not valid
+	if (a.Type < SortString) || (a.Type > SortNatural) {
+		return tserr.NotExistent("sort type " + strconv.Itoa(int(a.Type)))
+	}
+	// Return an error if the policy is not valid
+	if (a.Unparsable < UnparsableLast) || (a.Unparsable > UnparsableError) {
+		return tserr.NotExistent("unparsable policy " + strconv.Itoa(int(a.Unparsable)))
+	}
+	// Retrieve index i of column header h
+	i, e := t.find(h)
+	// Return an error if find returns an error
+	if e != nil {
+		return tserr.Op(&tserr.OpArgs{Op: "find", Fn: h, Err: e})
 	}
-	// Return without swap if i or j is higher or equal to the length of rows
-	if (i >= len(t.rows)) || (j >= len(t.rows)) {
-		return
+	// Return an error if the number of sort types does not equal the number of elements of the table header
+	if len(t.sorts) != len(t.header) {
+		return tserr.Equal(&tserr.EqualArgs{Var: "sort types", Actual: int64(len(t.sorts)), Want: int64(len(t.header))})
 	}
-	// Swap rows with indexes i and j
-	t.rows[i], t.rows[j] = t.rows[j], t.rows[i]
+	// Set sort type of column i to a
+	t.sorts[i] = *a
+	// Return nil
+	return nil
+}
+
+// A SortKey selects a column for sorting by its header and the direction.
+type SortKey struct {
+	Header     string // Header of the column
+	Descending bool   // Sort in descending order
 }
 
-// Less reports whether the row with index i must
-// sort before the row with index j.
-func (t byRow) Less(i, j int) bool {
-	// Return false in case rows is nil
-	if t.rows == nil {
-		return false
+// A sortColumn is a column for sorting given by its index and the direction.
+type sortColumn struct {
+	index int  // Index of the column
+	desc  bool // Sort in descending order
+}
+
+// A parsed cell is a cell parsed according to the sort type of its column.
+type parsed struct {
+	v  any  // Parsed cell
+	ok bool // False, if the cell cannot be parsed
+}
+
+// SortByKeys sets table t to be sorted by keys k. Rows are sorted by the first key, rows with equal cells by the
+// second key and so on. Rows with equal cells in all keys keep their insertion order. Each key is sorted ascending or
+// descending according to the sort type of its column. Unparsable cells are sorted first or last according to the
+// policy of the sort type regardless of the direction. It returns an error, if k is empty, if a column header cannot
+// be found in table t or if a column header is provided more than once.
+func (t *Table) SortByKeys(k ...SortKey) error {
+	// Return an error, if t is nil
+	if t == nil {
+		return tserr.NilPtr()
 	}
-	// Return false in case i or j is equal or greater than the number of rows
-	if (i >= len(t.rows)) || (j >= len(t.rows)) {
-		return false
+	// Return an error, if k is empty
+	if len(k) == 0 {
+		return tserr.Empty("sort keys")
 	}
-	// Return false in case key is equal or greater than the number of elements in rows with indexes i and j
-	if (t.key >= len(t.rows[i])) || (t.key >= len(t.rows[j])) {
-		return false
+	// Retrieve the column of each key
+	keys := make([]sortColumn, len(k))
+	for i, v := range k {
+		// Retrieve index of column header
+		j, e := t.find(v.Header)
+		// Return an error, if find returns an error
+		if e != nil {
+			return tserr.Op(&tserr.OpArgs{Op: "find", Fn: v.Header, Err: e})
+		}
+		// Return an error, if the column is provided more than once
+		if slices.ContainsFunc(keys[:i], func(c sortColumn) bool { return c.index == j }) {
+			return tserr.Duplicate("sort key " + v.Header)
+		}
+		keys[i] = sortColumn{index: j, desc: v.Descending}
 	}
-	// Return whether the row with index i must sort before the row with index j
-	// based on alphabetical order
-	return t.rows[i][t.key] < t.rows[j][t.key]
+	// Set sort keys
+	t.keys = keys
+	// Return nil
+	return nil
 }
 
-// sort sorts Table t by the selected row, which is given by the row index in field key.
-// Initially, the first row is selected. The selected row can be changed with function SortBy.
-func (t *Table) sort() error {
-	// Return error in case t is nil
+// SortByInsertion sets table t to keep the insertion order of its rows. The rows are printed and exported in the
+// order they were added. It returns an error, if t is nil.
+func (t *Table) SortByInsertion() error {
+	// Return an error, if t is nil
 	if t == nil {
 		return tserr.NilPtr()
 	}
-	// Sort Table t by the selected row
-	sort.Sort(byRow(*t))
+	// Remove sort keys
+	t.keys = nil
 	// Return nil
 	return nil
 }
+
+// compare returns -1, 0 or +1 depending on whether the parsed cell x sorts before, equal to or after the parsed cell y
+// in column c. The cells of the rows x and y are compared, if both are strings or cannot be parsed. The sort type a
+// defines the order of strings and unparsable cells.
+func (c sortColumn) compare(x, y parsed, rx, ry []string, a SortArgs) int {
+	var r int
+	switch {
+	case x.ok && y.ok && (x.v != nil):
+		// Compare parsed cells
+		r = sortCompare(x.v, y.v)
+	case x.ok == y.ok:
+		// Compare strings and unparsable cells
+		r = a.compareCells(rx[c.index], ry[c.index])
+	case x.ok == (a.Unparsable == UnparsableFirst):
+		// Sort the cell x after the unparsable cell y or the unparsable cell x after the cell y
+		return 1
+	default:
+		// Sort the unparsable cell x before the cell y or the cell x before the unparsable cell y
+		return -1
+	}
+	// Reverse the order, if the column is sorted in descending order
+	if c.desc {
+		return -r
+	}
+	return r
+}
+
+// sorted returns the rows of Table t sorted by the sort keys according to the sort type of each column. Rows with equal
+// cells in all keys are sorted by their insertion order. Without sort keys, the rows are returned in insertion order.
+// Initially, the table is sorted by the first column. The sort keys can be changed with SortBy, SortByKeys and
+// SortByInsertion. The rows of t are not modified, the returned slice is a permutation of the rows of t. It returns nil
+// and an error, if a cell cannot be parsed and the policy of the sort type is UnparsableError.
+func (t *Table) sorted() ([][]string, error) {
+	// Return nil and an error in case t is nil
+	if t == nil {
+		return nil, tserr.NilPtr()
+	}
+	// Parse the cells of each sort key
+	cells := make([][]parsed, len(t.keys))
+	for k, c := range t.keys {
+		// Return nil and an error if the column does not exist
+		if (c.index < 0) || (c.index >= len(t.sorts)) {
+			return nil, tserr.Lower(&tserr.LowerArgs{Var: "sort key", Actual: int64(c.index), HigherBound: int64(len(t.sorts))})
+		}
+		// Retrieve sort type of the column
+		a := t.sorts[c.index]
+		cells[k] = make([]parsed, len(t.rows))
+		for i, r := range t.rows {
+			// Return nil and an error if the row does not contain the column
+			if c.index >= len(r) {
+				return nil, tserr.Lower(&tserr.LowerArgs{Var: "sort key", Actual: int64(c.index), HigherBound: int64(len(r))})
+			}
+			v, e := a.parse(r[c.index])
+			// Return nil and an error, if the cell cannot be parsed and unparsable cells are not allowed
+			if (e != nil) && (a.Unparsable == UnparsableError) {
+				return nil, tserr.Op(&tserr.OpArgs{Op: "parse", Fn: r[c.index], Err: e})
+			}
+			cells[k][i] = parsed{v: v, ok: e == nil}
+		}
+	}
+	// Retrieve the row indexes in insertion order
+	perm := make([]int, len(t.rows))
+	for i := range perm {
+		perm[i] = i
+	}
+	// Sort the row indexes by the sort keys and by the insertion order
+	if len(t.keys) > 0 {
+		slices.SortStableFunc(perm, func(i, j int) int {
+			for k, c := range t.keys {
+				if r := c.compare(cells[k][i], cells[k][j], t.rows[i], t.rows[j], t.sorts[c.index]); r != 0 {
+					return r
+				}
+			}
+			return cmp.Compare(i, j)
+		})
+	}
+	// Return the rows in the order of the row indexes
+	rows := make([][]string, len(perm))
+	for i, p := range perm {
+		rows[i] = t.rows[p]
+	}
+	return rows, nil
+}
+
+// parse returns cell c parsed according to the sort type of a. It returns nil for strings, which are compared as
+// cells. It returns nil and an error, if c cannot be parsed.
+func (a SortArgs) parse(c string) (any, error) {
+	// Trim spaces of c, if it is not sorted as string
+	if a.Type != SortString {
+		c = strings.TrimSpace(c)
+	}
+	// Parse c according to the sort type
+	switch a.Type {
+	case SortInt:
+		return strconv.ParseInt(c, 10, 64)
+	case SortFloat:
+		f, e := strconv.ParseFloat(c, 64)
+		// Return nil and an error, if f is not a number
+		if (e == nil) && math.IsNaN(f) {
+			return nil, tserr.NotExistent("number " + c)
+		}
+		return f, e
+	case SortTime:
+		// Use default layout, if the layout is empty
+		l := a.Layout
+		if l == "" {
+			l = time.RFC3339
+		}
+		return time.Parse(l, c)
+	case SortDuration:
+		return time.ParseDuration(c)
+	case SortSize:
+		return sortSize(c)
+	case SortVersion:
+		return sortVersion(c)
+	case SortIP:
+		return netip.ParseAddr(c)
+	default:
+		// Strings are not parsed and compared as cells
+		return nil, nil
+	}
+}
+
+// sortCompare returns -1, 0 or +1 depending on whether parsed cell x is less than, equal to or greater than
+// parsed cell y. Cells x and y must be parsed with the same sort type.
+func sortCompare(x, y any) int {
+	switch v := x.(type) {
+	case int64:
+		return cmp.Compare(v, y.(int64))
+	case float64:
+		return cmp.Compare(v, y.(float64))
+	case time.Time:
+		return v.Compare(y.(time.Time))
+	case time.Duration:
+		return cmp.Compare(v, y.(time.Duration))
+	case version:
+		return v.compare(y.(version))
+	case netip.Addr:
+		return v.Compare(y.(netip.Addr))
+	default:
+		return 0
+	}
+}
+
+// compareCells returns -1, 0 or +1 depending on whether cell x is less than, equal to or greater than cell y. Cells of
+// SortNatural are compared in natural order, cells of SortString and unparsable cells in lexical order. Cells of
+// SortString and SortNatural are compared case-insensitively, if IgnoreCase is true.
+func (a SortArgs) compareCells(x, y string) int {
+	// Retrieve whether cells are compared in natural order and case-insensitively
+	natural := a.Type == SortNatural
+	fold := a.IgnoreCase && (natural || (a.Type == SortString))
+	// Compare cells in lexical order
+	if !natural && !fold {
+		return strings.Compare(x, y)
+	}
+	// Order of the first equal numbers with a different number of leading zeros
+	z := 0
+	// Compare cells rune by rune
+	for (len(x) > 0) && (len(y) > 0) {
+		// Compare numbers by their value, if the cells are compared in natural order
+		if natural && sortDigit(x[0]) && sortDigit(y[0]) {
+			// Retrieve the numbers and their values without leading zeros
+			i, j := sortDigits(x), sortDigits(y)
+			vx, vy := strings.TrimLeft(x[:i], "0"), strings.TrimLeft(y[:j], "0")
+			// A number with more digits is greater, numbers with the same number of digits compare in lexical order
+			if r := cmp.Compare(len(vx), len(vy)); r != 0 {
+				return r
+			}
+			if r := strings.Compare(vx, vy); r != 0 {
+				return r
+			}
+			// Equal numbers with less leading zeros are less, if the cells are otherwise equal
+			if z == 0 {
+				z = cmp.Compare(i, j)
+			}
+			x, y = x[i:], y[j:]
+			continue
+		}
+		// Retrieve the next runes
+		u, n := utf8.DecodeRuneInString(x)
+		v, m := utf8.DecodeRuneInString(y)
+		// Compare runes in lower case, if the cells are compared case-insensitively
+		if fold {
+			u, v = unicode.ToLower(u), unicode.ToLower(v)
+		}
+		if u != v {
+			return cmp.Compare(u, v)
+		}
+		x, y = x[n:], y[m:]
+	}
+	// The shorter cell is less
+	if r := cmp.Compare(len(x), len(y)); r != 0 {
+		return r
+	}
+	// Return the order of leading zeros
+	return z
+}
+
+// sortDigit returns true, if byte b is an ASCII digit.
+func sortDigit(b byte) bool {
+	return (b >= '0') && (b <= '9')
+}
+
+// sortDigits returns the number of leading ASCII digits of s.
+func sortDigits(s string) int {
+	i := 0
+	for (i < len(s)) && sortDigit(s[i]) {
+		i++
+	}
+	return i
+}
+
+// sortSize returns the number of bytes of byte size c, e.g., 200MB, 1.5 GiB or 4K. Units are case-insensitive. Units
+// with a single letter are binary multiples. It returns zero and an error, if c is not a byte size.
+func sortSize(c string) (float64, error) {
+	// Split c into number and unit
+	i := strings.IndexFunc(c, unicode.IsLetter)
+	if i < 0 {
+		i = len(c)
+	}
+	n, u := strings.TrimSpace(c[:i]), strings.ToLower(c[i:])
+	// Retrieve number
+	f, e := strconv.ParseFloat(n, 64)
+	// Return zero and an error, if the number cannot be parsed
+	if e != nil {
+		return 0, tserr.Op(&tserr.OpArgs{Op: "ParseFloat", Fn: n, Err: e})
+	}
+	// Retrieve number of bytes of the unit
+	m, ok := sortUnits[u]
+	// Return zero and an error, if the unit is unknown
+	if !ok {
+		return 0, tserr.NotExistent("unit " + u)
+	}
+	// Return number of bytes
+	return f * m, nil
+}
+
+// A version is a semantic version with numeric components and pre-release identifiers. Build metadata is ignored.
+type version struct {
+	num []uint64 // Numeric components, e.g., major, minor and patch
+	pre []string // Pre-release identifiers
+}
+
+// sortVersion returns semantic version c with an optional prefix v, e.g., v1.10.0 or 2.0.0-rc.1. Missing numeric
+// components are zero. It returns an empty version and an error, if c is not a semantic version.
+func sortVersion(c string) (version, error) {
+	// Remove prefix and build metadata
+	c = strings.TrimPrefix(strings.TrimPrefix(c, "v"), "V")
+	c, _, _ = strings.Cut(c, "+")
+	// Split c into numeric components and pre-release identifiers
+	c, p, hasPre := strings.Cut(c, "-")
+	var v version
+	for _, s := range strings.Split(c, ".") {
+		n, e := strconv.ParseUint(s, 10, 64)
+		// Return an empty version and an error, if a component is not numeric
+		if e != nil {
+			return version{}, tserr.Op(&tserr.OpArgs{Op: "ParseUint", Fn: s, Err: e})
+		}
+		v.num = append(v.num, n)
+	}
+	if hasPre {
+		v.pre = strings.Split(p, ".")
+		// Return an empty version and an error, if a pre-release identifier is empty
+		for _, s := range v.pre {
+			if s == "" {
+				return version{}, tserr.Empty("pre-release identifier")
+			}
+		}
+	}
+	// Return version
+	return v, nil
+}
+
+// compare returns -1, 0 or +1 depending on whether version v is less than, equal to or greater than version w.
+// A version with pre-release identifiers is less than the version without.
+func (v version) compare(w version) int {
+	// Compare numeric components, missing components are zero
+	for i := 0; i < max(len(v.num), len(w.num)); i++ {
+		var x, y uint64
+		if i < len(v.num) {
+			x = v.num[i]
+		}
+		if i < len(w.num) {
+			y = w.num[i]
+		}
+		if c := cmp.Compare(x, y); c != 0 {
+			return c
+		}
+	}
+	// A version without pre-release identifiers is greater
+	switch {
+	case (len(v.pre) == 0) && (len(w.pre) == 0):
+		return 0
+	case len(v.pre) == 0:
+		return 1
+	case len(w.pre) == 0:
+		return -1
+	}
+	// Compare pre-release identifiers. Numeric identifiers are less than other identifiers.
+	for i := 0; i < min(len(v.pre), len(w.pre)); i++ {
+		x, ex := strconv.ParseUint(v.pre[i], 10, 64)
+		y, ey := strconv.ParseUint(w.pre[i], 10, 64)
+		var c int
+		switch {
+		case (ex == nil) && (ey == nil):
+			c = cmp.Compare(x, y)
+		case ex == nil:
+			c = -1
+		case ey == nil:
+			c = 1
+		default:
+			c = strings.Compare(v.pre[i], w.pre[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	// A larger set of pre-release identifiers is greater
+	return cmp.Compare(len(v.pre), len(w.pre))
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable_test
+
+// Import Go standard library packages as well as tstable and tserr
+import (
+	"bytes"   // bytes
+	"fmt"     // fmt
+	"slices"  // slices
+	"strings" // strings
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tstable" // tstable
+)
+
+// testSortTable returns a table with column Key containing cells c and column Index containing the index of each cell.
+func testSortTable(t *testing.T, c []string) *tstable.Table {
+	// Retrieve new table
+	tbl, e := tstable.New([]string{"Key", "Index"})
+	// The test fails if New returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "New", Fn: "table", Err: e}))
+	}
+	// Add a row for each cell
+	for i, v := range c {
+		if e := tbl.AddRow([]string{v, fmt.Sprint(i)}); e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: v, Err: e}))
+		}
+	}
+	// Return table
+	return tbl
+}
+
+// testSorted returns the cells of the first column of table tbl in the order of Print. The test fails if CSV returns an error.
+func testSorted(t *testing.T, tbl *tstable.Table) []string {
+	// Retrieve CSV representation with the rows in the order of Print
+	var b bytes.Buffer
+	if e := tbl.CSV(&b, ';'); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "CSV", Fn: "table", Err: e}))
+	}
+	// Retrieve the first cell of each row without the header
+	l := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")[1:]
+	c := make([]string, len(l))
+	for i, r := range l {
+		c[i], _, _ = strings.Cut(r, ";")
+	}
+	// Return cells
+	return c
+}
+
+// evalSorted fails the test, if the cells c do not equal want.
+func evalSorted(t *testing.T, name string, c, want []string) {
+	if !slices.Equal(c, want) {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: name, Actual: fmt.Sprint(c), Want: fmt.Sprint(want)}))
+	}
+}
+
+// TestSortTypes tests sorting a column with each sort type. The test fails if the rows are not sorted as expected.
+func TestSortTypes(t *testing.T) {
+	for name, c := range map[string]struct {
+		a    tstable.SortArgs
+		want []string
+	}{
+		"String":   {tstable.SortArgs{}, []string{"10", "200MB", "9", "a"}},
+		"Int":      {tstable.SortArgs{Type: tstable.SortInt}, []string{"-3", "9", "+10", "100"}},
+		"Float":    {tstable.SortArgs{Type: tstable.SortFloat}, []string{"-1e3", "0.5", "1.5", "10"}},
+		"Time":     {tstable.SortArgs{Type: tstable.SortTime, Layout: "02.01.2006"}, []string{"31.12.2022", "01.01.2023", "02.01.2023", "15.03.2023"}},
+		"RFC3339":  {tstable.SortArgs{Type: tstable.SortTime}, []string{"2023-01-01T10:00:00+02:00", "2023-01-01T09:00:00Z", "2023-01-02T00:00:00Z"}},
+		"Duration": {tstable.SortArgs{Type: tstable.SortDuration}, []string{"250ms", "3s", "1m", "1h30m"}},
+		"Size":     {tstable.SortArgs{Type: tstable.SortSize}, []string{"512", "1K", "1.5kB", "200MB", "1.5GB", "2GiB"}},
+		"Version":  {tstable.SortArgs{Type: tstable.SortVersion}, []string{"v1.2", "1.9.0", "v1.10.0-alpha", "v1.10.0-alpha.1", "v1.10.0-beta", "1.10.0+build.5", "2.0.0"}},
+		"IP":       {tstable.SortArgs{Type: tstable.SortIP}, []string{"9.9.9.9", "10.0.0.2", "10.0.0.10", "::1", "fe80::1"}},
+		"Natural":  {tstable.SortArgs{Type: tstable.SortNatural}, []string{"Node10", "node2", "node10", "node010", "node10a", "v1.9", "v1.10", "v1.10.1"}},
+		"NaturalI": {tstable.SortArgs{Type: tstable.SortNatural, IgnoreCase: true}, []string{"node2", "Node10", "node10a", "shard-7", "Shard-12"}},
+		"StringI":  {tstable.SortArgs{IgnoreCase: true}, []string{"aragorn", "Boromir", "gimli", "Legolas"}},
+	} {
+		// Retrieve table with reversed cells
+		r := slices.Clone(c.want)
+		slices.Reverse(r)
+		tbl := testSortTable(t, r)
+		// Set sort type of column Key
+		if e := tbl.SetSortType("Key", &c.a); e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "SetSortType", Fn: name, Err: e}))
+		}
+		// Evaluate the order of the rows
+		evalSorted(t, name, testSorted(t, tbl), c.want)
+	}
+}
+
+// TestSortUnparsable tests the policies for unparsable cells. The test fails if the rows are not sorted as expected
+// or if Print does not return an error for UnparsableError.
+func TestSortUnparsable(t *testing.T) {
+	c := []string{"n/a", "10", "", "9"}
+	// Sort unparsable cells last per default and first with UnparsableFirst
+	for p, want := range map[tstable.Unparsable][]string{
+		tstable.UnparsableLast:  {"9", "10", "", "n/a"},
+		tstable.UnparsableFirst: {"", "n/a", "9", "10"},
+	} {
+		tbl := testSortTable(t, c)
+		if e := tbl.SetSortType("Key", &tstable.SortArgs{Type: tstable.SortInt, Unparsable: p}); e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "SetSortType", Fn: "Key", Err: e}))
+		}
+		evalSorted(t, fmt.Sprint(p), testSorted(t, tbl), want)
+	}
+	// The test fails if Print does not return an error for an unparsable cell with UnparsableError
+	tbl := testSortTable(t, c)
+	if e := tbl.SetSortType("Key", &tstable.SortArgs{Type: tstable.SortInt, Unparsable: tstable.UnparsableError}); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "SetSortType", Fn: "Key", Err: e}))
+	}
+	if _, e := tbl.Print(); e == nil {
+		t.Error(tserr.NilFailed("Print"))
+	}
+}
+
+// TestSetSortTypeErr tests SetSortType with a nil table, a column which does not exist, an invalid sort type and an
+// invalid policy. The test fails if SetSortType does not return an error.
+func TestSetSortTypeErr(t *testing.T) {
+	// The test fails if SetSortType does not return an error for a nil table
+	var tbl *tstable.Table
+	if e := tbl.SetSortType("Key", nil); e == nil {
+		t.Error(tserr.NilFailed("SetSortType"))
+	}
+	// Test cases with column header and sort arguments
+	tbl = testSortTable(t, nil)
+	for h, a := range map[string]*tstable.SortArgs{
+		"Date":  nil,
+		"Key":   {Type: -1},
+		"Index": {Unparsable: tstable.UnparsableError + 1},
+		"Value": {Type: tstable.SortNatural + 1},
+	} {
+		// The test fails if SetSortType does not return an error
+		if e := tbl.SetSortType(h, a); e == nil {
+			t.Error(tserr.NilFailed("SetSortType"))
+		}
+	}
+}
+
+// TestSortByKeys tests sorting the test table by Weapon descending and by Title ascending as well as the insertion order
+// as final tie-breaker after sorting by another column. The test fails if the rows are not sorted as expected.
+func TestSortByKeys(t *testing.T) {
+	// Retrieve test table
+	tbl := testTable(t)
+	// Sort by Weapon descending and Title ascending
+	if e := tbl.SortByKeys(tstable.SortKey{Header: "Weapon", Descending: true}, tstable.SortKey{Header: "Title"}); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "SortByKeys", Fn: "table", Err: e}))
+	}
+	evalSorted(t, "SortByKeys", testSorted(t, tbl), []string{"Gandalf", "Boromir", "Aragorn", "Legolas", "Gimli"})
+	// Sort by Weapon after sorting by Weapon and Title. Rows with equal weapons keep their insertion order.
+	if e := tbl.SortBy("Weapon"); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "SortBy", Fn: "table", Err: e}))
+	}
+	evalSorted(t, "SortBy", testSorted(t, tbl), []string{"Gimli", "Legolas", "Aragorn", "Boromir", "Gandalf"})
+}
+
+// TestSortByKeysStable tests sorting many rows with equal keys. The test fails if the rows do not keep their
+// insertion order.
+func TestSortByKeysStable(t *testing.T) {
+	// Retrieve table with alternating keys
+	c := make([]string, 100)
+	for i := range c {
+		c[i] = fmt.Sprint(i % 2)
+	}
+	tbl := testSortTable(t, c)
+	// Sort by Key descending
+	if e := tbl.SortByKeys(tstable.SortKey{Header: "Key", Descending: true}); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "SortByKeys", Fn: "table", Err: e}))
+	}
+	// Retrieve rows in the order of Print
+	var b bytes.Buffer
+	if e := tbl.CSV(&b, ';'); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "CSV", Fn: "table", Err: e}))
+	}
+	// The test fails if the rows are not sorted by Key descending and their insertion index
+	want := []string{"Key;Index"}
+	for k := 1; k >= 0; k-- {
+		for i := k; i < len(c); i += 2 {
+			want = append(want, fmt.Sprintf("%d;%d", k, i))
+		}
+	}
+	evalSorted(t, "SortByKeysStable", strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n"), want)
+}
+
+// TestSortByKeysUnparsable tests sorting unparsable cells descending. The test fails if the unparsable cells are not last.
+func TestSortByKeysUnparsable(t *testing.T) {
+	tbl := testSortTable(t, []string{"n/a", "9", "", "10"})
+	if e := tbl.SetSortType("Key", &tstable.SortArgs{Type: tstable.SortInt}); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "SetSortType", Fn: "Key", Err: e}))
+	}
+	if e := tbl.SortByKeys(tstable.SortKey{Header: "Key", Descending: true}); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "SortByKeys", Fn: "table", Err: e}))
+	}
+	evalSorted(t, "SortByKeysUnparsable", testSorted(t, tbl), []string{"10", "9", "n/a", ""})
+}
+
+// TestSortByKeysErr tests SortByKeys with a nil table, without keys, with a column which does not exist and with
+// a duplicate column. The test fails if SortByKeys does not return an error.
+func TestSortByKeysErr(t *testing.T) {
+	// The test fails if SortByKeys does not return an error for a nil table
+	var tbl *tstable.Table
+	if e := tbl.SortByKeys(tstable.SortKey{Header: "Key"}); e == nil {
+		t.Error(tserr.NilFailed("SortByKeys"))
+	}
+	// Test cases with sort keys
+	tbl = testSortTable(t, nil)
+	for _, k := range [][]tstable.SortKey{
+		nil,
+		{{Header: "Date"}},
+		{{Header: "Key"}, {Header: "Index"}, {Header: "Key", Descending: true}},
+	} {
+		// The test fails if SortByKeys does not return an error
+		if e := tbl.SortByKeys(k...); e == nil {
+			t.Error(tserr.NilFailed("SortByKeys"))
+		}
+	}
+}
+
+// TestSortByInsertion tests keeping the insertion order after the table has been printed sorted by another column.
+// The test fails if the rows are not in insertion order or if printing the table twice differs.
+func TestSortByInsertion(t *testing.T) {
+	// Retrieve test table and print it sorted by Weapon
+	tbl := testTable(t)
+	s, e := tbl.Print()
+	// The test fails if Print returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Print", Fn: "table", Err: e}))
+	}
+	// The test fails if printing the table again differs
+	if p, _ := tbl.Print(); p != s {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "Print", Actual: p, Want: s}))
+	}
+	// Keep the insertion order
+	if e := tbl.SortByInsertion(); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "SortByInsertion", Fn: "table", Err: e}))
+	}
+	evalSorted(t, "SortByInsertion", testSorted(t, tbl), []string{"Gandalf", "Aragorn", "Legolas", "Gimli", "Boromir"})
+	// The test fails if SortByInsertion does not return an error for a nil table
+	var n *tstable.Table
+	if e := n.SortByInsertion(); e == nil {
+		t.Error(tserr.NilFailed("SortByInsertion"))
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable
+
+// Import Go standard packages and tserr
+import (
+	"regexp"  // regexp
+	"slices"  // slices
+	"strconv" // strconv
+	"strings" // strings
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// An SQLDialect defines the quoting of identifiers and literals in an SQL script.
+type SQLDialect int
+
+const (
+	SQLPostgreSQL SQLDialect = iota // PostgreSQL with "identifiers" (default)
+	SQLMySQL                        // MySQL with `identifiers` and escaped backslashes in literals
+	SQLSQLite                       // SQLite with "identifiers"
+)
+
+// Default values for the SQL representation of a table
+const (
+	sqlTable string = "data" // Default table name
+	sqlType  string = "TEXT" // Default column type
+	sqlBatch int    = 100    // Default number of rows per INSERT statement
+)
+
+// sqlTypeRegexp matches a valid column type like TEXT, INTEGER or VARCHAR(255)
+var sqlTypeRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_ ]*(\([0-9, ]+\))?$`)
+
+// SQLArgs holds the arguments for the SQL representation of a table. Empty fields are set to default values.
+//
+//	Dialect:	SQL dialect, SQLPostgreSQL, SQLMySQL or SQLSQLite
+//	Table:		name of the SQL table, sanitized like column names (default data)
+//	Types:		column types by header name (default TEXT)
+//	Batch:		maximum number of rows per INSERT statement (default 100)
+type SQLArgs struct {
+	Dialect SQLDialect        // SQL dialect
+	Table   string            // Table name
+	Types   map[string]string // Column types by header name
+	Batch   int               // Rows per INSERT statement
+}
+
+// SQL returns table t as an SQL script with a CREATE TABLE statement followed by batched INSERT statements. The column
+// identifiers are derived from the header: they are converted to lower case, runes other than a-z and 0-9 are replaced
+// by _, identifiers starting with a digit are prefixed with _ and duplicates are suffixed with _2, _3, ... Identifiers
+// are quoted and literals are escaped for the SQL dialect. Columns have the type from Types or TEXT. Empty cells are
+// empty strings in columns with a text type like TEXT or VARCHAR(255) and NULL in columns with any other type like
+// INTEGER, REAL or TIMESTAMP, which cannot hold an empty string. If a is nil, default values are used. The rows are
+// sorted in the same order as with Print. It returns an empty string and an error, if any.
+func (t *Table) SQL(a *SQLArgs) (string, error) {
+	// Retrieve view of t
+	v, e := t.view()
+	// Return an empty string and an error if view fails
+	if e != nil {
+		return "", tserr.Op(&tserr.OpArgs{Op: "view", Fn: "table", Err: e})
+	}
+	// Return SQL representation
+	return v.sql(a)
+}
+
+// sql returns view v as an SQL script.
+func (v *View) sql(a *SQLArgs) (string, error) {
+	// Use default arguments, if a is nil
+	if a == nil {
+		a = &SQLArgs{}
+	}
+	// Return an empty string and an error, if the dialect is unknown
+	if (a.Dialect < SQLPostgreSQL) || (a.Dialect > SQLSQLite) {
+		return "", tserr.NotExistent("SQL dialect " + strconv.Itoa(int(a.Dialect)))
+	}
+	// Return an empty string and an error, if the batch size is negative
+	if a.Batch < 0 {
+		return "", tserr.Higher(&tserr.HigherArgs{Var: "batch", Actual: int64(a.Batch), LowerBound: 0})
+	}
+	// Set default values
+	name, batch := a.Table, a.Batch
+	if name == "" {
+		name = sqlTable
+	}
+	if batch == 0 {
+		batch = sqlBatch
+	}
+	// Retrieve column types and whether the column type is a text type
+	types, text := make([]string, len(v.header)), make([]bool, len(v.header))
+	for i, h := range v.header {
+		types[i] = sqlType
+		if typ, ok := a.Types[h]; ok {
+			// Return an empty string and an error, if the column type is invalid
+			if !sqlTypeRegexp.MatchString(typ) {
+				return "", tserr.Forbidden("column type " + typ)
+			}
+			types[i] = typ
+		}
+		text[i] = sqlText(types[i])
+	}
+	// Return an empty string and an error, if a type refers to a column which does not exist
+	for h := range a.Types {
+		if !slices.Contains(v.header, h) {
+			return "", tserr.NotExistent(h)
+		}
+	}
+	// Sanitize and quote table name and column identifiers
+	table := sqlQuoteIdent(sqlIdent(name, "data"), a.Dialect)
+	cols := sqlIdents(v.header)
+	for i, c := range cols {
+		cols[i] = sqlQuoteIdent(c, a.Dialect)
+	}
+	var b strings.Builder
+	// Write CREATE TABLE statement
+	b.WriteString("CREATE TABLE " + table + " (\n")
+	for i, c := range cols {
+		b.WriteString("  " + c + " " + types[i])
+		if i < len(cols)-1 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(");\n")
+	// Write INSERT statements with at most batch rows
+	insert := "INSERT INTO " + table + " (" + strings.Join(cols, ", ") + ") VALUES\n"
+	for i, r := range v.rows {
+		if i%batch == 0 {
+			b.WriteString(insert)
+		}
+		// Write quoted literals of row r and NULL for empty cells of columns without a text type
+		l := make([]string, len(r))
+		for j, c := range r {
+			if (c == "") && !text[j] {
+				l[j] = "NULL"
+				continue
+			}
+			l[j] = sqlQuoteLiteral(c, a.Dialect)
+		}
+		b.WriteString("  (" + strings.Join(l, ", ") + ")")
+		// End the statement after the last row of a batch
+		if (i%batch == batch-1) || (i == len(v.rows)-1) {
+			b.WriteString(";\n")
+		} else {
+			b.WriteString(",\n")
+		}
+	}
+	// Return SQL representation
+	return b.String(), nil
+}
+
+// sqlIdents returns sanitized, unique identifiers for header h. Duplicates are suffixed with _2, _3, ...
+func sqlIdents(h []string) []string {
+	// Allocate identifiers and set of taken identifiers
+	ids, taken := make([]string, len(h)), make(map[string]bool, len(h))
+	// Iterate header names
+	for i, v := range h {
+		id := sqlIdent(v, "column_"+strconv.Itoa(i+1))
+		// Append the lowest suffix resulting in an identifier which is not taken
+		k := id
+		for n := 2; taken[k]; n++ {
+			k = id + "_" + strconv.Itoa(n)
+		}
+		ids[i], taken[k] = k, true
+	}
+	// Return identifiers
+	return ids
+}
+
+// sqlIdent returns the sanitized identifier for name n. It is converted to lower case and runes other than a-z and 0-9
+// are replaced by _ with repeated _ collapsed. An identifier starting with a digit is prefixed with _. If the
+// identifier is empty, it returns d.
+func sqlIdent(n, d string) string {
+	var b strings.Builder
+	// Iterate runes of n in lower case
+	for _, r := range strings.ToLower(n) {
+		// Keep a-z and 0-9
+		if ((r >= 'a') && (r <= 'z')) || ((r >= '0') && (r <= '9')) {
+			b.WriteRune(r)
+			continue
+		}
+		// Replace any other rune by _, if the previous rune is not already _
+		if s := b.String(); !strings.HasSuffix(s, "_") {
+			b.WriteByte('_')
+		}
+	}
+	// Remove leading and trailing _
+	id := strings.Trim(b.String(), "_")
+	// Return d, if the identifier is empty
+	if id == "" {
+		return d
+	}
+	// Prefix identifiers starting with a digit
+	if (id[0] >= '0') && (id[0] <= '9') {
+		id = "_" + id
+	}
+	// Return identifier
+	return id
+}
+
+// sqlQuoteIdent returns identifier id quoted for dialect d.
+func sqlQuoteIdent(id string, d SQLDialect) string {
+	if d == SQLMySQL {
+		return "`" + id + "`"
+	}
+	return `"` + id + `"`
+}
+
+// sqlText returns true, if column type typ is a text type. Like the type affinity of SQLite, a type is a text type,
+// if its name contains CHAR, CLOB or TEXT, e.g., VARCHAR(255), NCHAR or MEDIUMTEXT.
+func sqlText(typ string) bool {
+	u := strings.ToUpper(typ)
+	return strings.Contains(u, "CHAR") || strings.Contains(u, "CLOB") || strings.Contains(u, "TEXT")
+}
+
+// sqlQuoteLiteral returns c as a quoted string literal for dialect d. Single quotes are doubled and for
+// MySQL backslashes are escaped.
+func sqlQuoteLiteral(c string, d SQLDialect) string {
+	if d == SQLMySQL {
+		c = strings.ReplaceAll(c, `\`, `\\`)
+	}
+	return "'" + strings.ReplaceAll(c, "'", "''") + "'"
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable_test
+
+// Import Go standard library package testing as well as tstable and tserr
+import (
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tstable" // tstable
+)
+
+// TestSQL tests the SQL representations of the test table. The test fails if an SQL representation
+// does not equal to the contents of the test data golden file.
+func TestSQL(t *testing.T) {
+	// Test cases with golden file names and SQL arguments
+	tc := map[string]*tstable.SQLArgs{
+		"SQLPostgreSQL": nil,
+		"SQLMySQL":      {Dialect: tstable.SQLMySQL, Table: "Fellowship", Types: map[string]string{"Weapon": "VARCHAR(32)"}, Batch: 2},
+		"SQLSQLite":     {Dialect: tstable.SQLSQLite, Table: "fellowship", Batch: 5},
+	}
+	// Retrieve test table
+	tbl := testTable(t)
+	// Iterate all test cases
+	for name, a := range tc {
+		// Retrieve SQL representation
+		s, e := tbl.SQL(a)
+		// The test fails if SQL returns an error
+		if e != nil {
+			t.Error(tserr.Op(&tserr.OpArgs{Op: "SQL", Fn: name, Err: e}))
+		}
+		// Evaluate the SQL representation
+		evalGolden(name, s, t)
+	}
+}
+
+// TestSQLQuote tests sanitizing of column identifiers and quoting of literals. The test fails
+// if the SQL representation does not match.
+func TestSQLQuote(t *testing.T) {
+	// Retrieve new test table with header names to be sanitized
+	tbl, e := tstable.New([]string{"Name", "name", "1st Place", "", "Größe"})
+	// The test fails if New returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "New", Fn: "table", Err: e}))
+	}
+	// Add a row with literals to be quoted
+	if e := tbl.AddRow([]string{"O'Brien", `a\b`, "", "x", "y"}); e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: "table", Err: e}))
+	}
+	// Retrieve SQL representation
+	s, e := tbl.SQL(&tstable.SQLArgs{Dialect: tstable.SQLMySQL})
+	// The test fails if SQL returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "SQL", Fn: "table", Err: e}))
+	}
+	// The test fails if the SQL representation does not match
+	want := "CREATE TABLE `data` (\n  `name` TEXT,\n  `name_2` TEXT,\n  `_1st_place` TEXT,\n  `column_4` TEXT,\n  `gr_e` TEXT\n);\n" +
+		"INSERT INTO `data` (`name`, `name_2`, `_1st_place`, `column_4`, `gr_e`) VALUES\n  ('O''Brien', 'a\\\\b', '', 'x', 'y');\n"
+	if s != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "SQL", Actual: s, Want: want}))
+	}
+}
+
+// TestSQLNull tests empty cells in columns with text types and other types. The test fails if empty cells in
+// columns without a text type are not NULL.
+func TestSQLNull(t *testing.T) {
+	// Retrieve new test table
+	tbl, e := tstable.New([]string{"Name", "Code", "Age", "Height", "Born"})
+	// The test fails if New returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "New", Fn: "table", Err: e}))
+	}
+	// Add a row with empty cells
+	if e := tbl.AddRow([]string{"", "", "", "", ""}); e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: "table", Err: e}))
+	}
+	// Retrieve SQL representation with text types and other types
+	s, e := tbl.SQL(&tstable.SQLArgs{Types: map[string]string{"Code": "varchar(8)", "Age": "INTEGER", "Height": "REAL", "Born": "TIMESTAMP"}})
+	// The test fails if SQL returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "SQL", Fn: "table", Err: e}))
+	}
+	// The test fails if the SQL representation does not match
+	want := "CREATE TABLE \"data\" (\n  \"name\" TEXT,\n  \"code\" varchar(8),\n  \"age\" INTEGER,\n  \"height\" REAL,\n  \"born\" TIMESTAMP\n);\n" +
+		"INSERT INTO \"data\" (\"name\", \"code\", \"age\", \"height\", \"born\") VALUES\n  ('', '', NULL, NULL, NULL);\n"
+	if s != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "SQL", Actual: s, Want: want}))
+	}
+}
+
+// TestSQLErr tests SQL to return an error in case of invalid arguments. The test fails if SQL returns a nil error.
+func TestSQLErr(t *testing.T) {
+	// Retrieve test table
+	tbl := testTable(t)
+	// Iterate invalid SQL arguments
+	for _, a := range []*tstable.SQLArgs{
+		{Dialect: tstable.SQLDialect(-1)},
+		{Batch: -1},
+		{Types: map[string]string{"Weapon": "TEXT; DROP TABLE data"}},
+		{Types: map[string]string{"Date of Birth": "DATE"}},
+	} {
+		// The test fails if SQL returns a nil error
+		if _, e := tbl.SQL(a); e == nil {
+			t.Error(tserr.NilFailed("SQL"))
+		}
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable
+
+// Import Go standard packages and tserr
+import (
+	"database/sql" // sql
+	"reflect"      // reflect
+	"strconv"      // strconv
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// SQLRowsArgs holds the arguments for tables from SQL result sets. Empty fields are set to default values.
+//
+//	Null:		cell for NULL values, e.g., a pointer to an empty string for empty cells (default NULL, if nil)
+//	Layout:		layout of time.Time values (default RFC3339)
+//	NonPrintable:	handling of non-printable runes in cells (default NonPrintableError)
+type SQLRowsArgs struct {
+	Null         *string      // Cell for NULL values
+	Layout       string       // Layout of time.Time values
+	NonPrintable NonPrintable // Policy for non-printable runes
+}
+
+// sqlNull is the default cell for NULL values
+const sqlNull string = "NULL"
+
+// FromSQLRows returns a new Table from the result set r. The header are the column names of r. Each row of r is scanned
+// and its values are converted to cells: NULL values are set to Null, []byte values are converted to strings, time.Time
+// values are formatted with Layout and numeric and other values are converted like with FromStructs. Non-printable
+// runes are handled according to the NonPrintable policy in a. If a is nil, default values are used. r is consumed and
+// closed. It returns nil and an error, if r is nil, if the column names cannot be retrieved, if scanning fails or if
+// a row cannot be added.
+func FromSQLRows(r *sql.Rows, a *SQLRowsArgs) (*Table, error) {
+	// Return nil and an error if r is nil
+	if r == nil {
+		return nil, tserr.NilPtr()
+	}
+	// Close r on return
+	defer r.Close()
+	// Use default arguments, if a is nil
+	if a == nil {
+		a = &SQLRowsArgs{}
+	}
+	// Set default values. An empty cell for NULL values is set with a pointer to an empty string.
+	null, c := sqlNull, structField{layout: a.Layout}
+	if a.Null != nil {
+		null = *a.Null
+	}
+	if c.layout == "" {
+		c.layout = structLayout
+	}
+	// Retrieve column names
+	h, e := r.Columns()
+	if e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "Columns", Fn: "rows", Err: e})
+	}
+	// Retrieve new table with column names as header
+	t, e := newImport(h, a.NonPrintable)
+	if e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "newImport", Fn: "rows", Err: e})
+	}
+	// Allocate destinations for scanning
+	v, p := make([]any, len(h)), make([]any, len(h))
+	for i := range v {
+		p[i] = &v[i]
+	}
+	// Scan rows
+	for n := 0; r.Next(); n++ {
+		if e := r.Scan(p...); e != nil {
+			return nil, tserr.Op(&tserr.OpArgs{Op: "Scan", Fn: "row " + strconv.Itoa(n), Err: e})
+		}
+		// Convert values to cells
+		row := make([]string, len(h))
+		for i, x := range v {
+			switch y := x.(type) {
+			case nil:
+				row[i] = null
+			case []byte:
+				row[i] = string(y)
+			default:
+				row[i] = c.cell(reflect.ValueOf(&y).Elem())
+			}
+		}
+		// Add row to the table
+		if e := t.addImport(row, a.NonPrintable); e != nil {
+			return nil, tserr.Op(&tserr.OpArgs{Op: "addImport", Fn: "row " + strconv.Itoa(n), Err: e})
+		}
+	}
+	// Return nil and an error, if iterating the rows fails
+	if e := r.Err(); e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "Next", Fn: "rows", Err: e})
+	}
+	// Return table
+	return t, nil
+}
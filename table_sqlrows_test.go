@@ -0,0 +1,158 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable_test
+
+// Import Go standard library packages as well as tstable and tserr
+import (
+	"database/sql"        // sql
+	"database/sql/driver" // driver
+	"errors"              // errors
+	"io"                  // io
+	"testing"             // testing
+	"time"                // time
+
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tstable" // tstable
+)
+
+// fakeDriver is a database driver returning a fixed result set for any query. The query "fail" returns an error
+// while iterating the rows.
+type fakeDriver struct{}
+
+// fakeConn is a connection of fakeDriver
+type fakeConn struct{}
+
+// fakeStmt is a statement of fakeConn
+type fakeStmt struct {
+	query string // Query of the statement
+}
+
+// fakeRows is the result set of fakeStmt
+type fakeRows struct {
+	fail bool             // Return an error after the first row
+	rows [][]driver.Value // Remaining rows
+}
+
+// errFake is the error returned by fakeRows for the query "fail"
+var errFake = errors.New("connection lost")
+
+// Register fakeDriver
+func init() {
+	sql.Register("tstable-fake", fakeDriver{})
+}
+
+// Open returns a new connection.
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+// Prepare returns a statement for query q.
+func (fakeConn) Prepare(q string) (driver.Stmt, error) { return &fakeStmt{query: q}, nil }
+
+// Close closes the connection.
+func (fakeConn) Close() error { return nil }
+
+// Begin is not supported.
+func (fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+// Close closes the statement.
+func (*fakeStmt) Close() error { return nil }
+
+// NumInput returns zero inputs.
+func (*fakeStmt) NumInput() int { return 0 }
+
+// Exec is not supported.
+func (*fakeStmt) Exec([]driver.Value) (driver.Result, error) { return nil, errors.New("not supported") }
+
+// Query returns the fixed result set.
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{fail: s.query == "fail", rows: [][]driver.Value{
+		{int64(1), "node1", []byte("eu-west"), 0.5, true, time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)},
+		{int64(2), "node2", nil, nil, false, nil},
+	}}, nil
+}
+
+// Columns returns the column names.
+func (*fakeRows) Columns() []string { return []string{"id", "host", "region", "load", "up", "since"} }
+
+// Close closes the rows.
+func (*fakeRows) Close() error { return nil }
+
+// Next copies the next row to dest.
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	if r.fail && (len(r.rows) == 1) {
+		return errFake
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+// testQuery returns the result set of query q with fakeDriver.
+func testQuery(t *testing.T, q string) *sql.Rows {
+	// Open database
+	db, e := sql.Open("tstable-fake", "")
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Open", Fn: "database", Err: e}))
+	}
+	t.Cleanup(func() { db.Close() })
+	// Query database
+	r, e := db.Query(q)
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Query", Fn: q, Err: e}))
+	}
+	// Return result set
+	return r
+}
+
+// TestFromSQLRows tests a table from a result set with NULL values, []byte, numeric, bool and time.Time values as
+// well as the default and an empty cell for NULL values. The test fails if FromSQLRows returns an error or if the
+// Markdown representation does not match.
+func TestFromSQLRows(t *testing.T) {
+	// Retrieve table from result set
+	null := "∅"
+	tbl, e := tstable.FromSQLRows(testQuery(t, "SELECT"), &tstable.SQLRowsArgs{Null: &null, Layout: time.DateOnly})
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "FromSQLRows", Fn: "rows", Err: e}))
+	}
+	// The test fails if the Markdown representation does not match
+	want := "| id | host | region | load | up | since |\n" +
+		"| --- | --- | --- | --- | --- | --- |\n" +
+		"| 1 | node1 | eu-west | 0.5 | true | 2023-05-01 |\n" +
+		"| 2 | node2 | ∅ | ∅ | false | ∅ |\n"
+	if s, _ := tbl.Markdown(); s != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "FromSQLRows", Actual: s, Want: want}))
+	}
+	// The test fails if NULL is not the default cell for NULL values
+	tbl, e = tstable.FromSQLRows(testQuery(t, "SELECT"), nil)
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "FromSQLRows", Fn: "rows", Err: e}))
+	}
+	if r, _ := tstable.ToStructs[struct{ Region string }](tbl); (len(r) != 2) || (r[1].Region != "NULL") {
+		t.Error(tserr.NotExistent("NULL"))
+	}
+	// The test fails if NULL values are not empty cells with an empty string for Null
+	null = ""
+	tbl, e = tstable.FromSQLRows(testQuery(t, "SELECT"), &tstable.SQLRowsArgs{Null: &null})
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "FromSQLRows", Fn: "rows", Err: e}))
+	}
+	if r, _ := tstable.ToStructs[struct{ Region string }](tbl); (len(r) != 2) || (r[1].Region != "") {
+		t.Error(tserr.NotExistent("empty cell"))
+	}
+}
+
+// TestFromSQLRowsErr tests FromSQLRows with nil rows and rows failing while iterating. The test fails if FromSQLRows
+// does not return an error or if the error of the rows is not wrapped.
+func TestFromSQLRowsErr(t *testing.T) {
+	// The test fails if FromSQLRows does not return an error for nil rows
+	if _, e := tstable.FromSQLRows(nil, nil); e == nil {
+		t.Error(tserr.NilFailed("FromSQLRows"))
+	}
+	// The test fails if FromSQLRows does not return the wrapped error of the rows
+	if _, e := tstable.FromSQLRows(testQuery(t, "fail"), nil); !errors.Is(e, errFake) {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "FromSQLRows", Fn: "rows", Err: e}))
+	}
+}
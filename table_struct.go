@@ -0,0 +1,364 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable
+
+// Import Go standard packages and tserr
+import (
+	"encoding" // encoding
+	"fmt"      // fmt
+	"reflect"  // reflect
+	"strconv"  // strconv
+	"strings"  // strings
+	"time"     // time
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// structTag is the key of struct tags evaluated by FromStructs
+const structTag string = "tstable"
+
+// structLayout is the default layout of time.Time fields
+const structLayout string = time.RFC3339
+
+// structAlign maps the value of tag option align to a column alignment
+var structAlign = map[string]Alignment{
+	"left":   AlignLeft,
+	"right":  AlignRight,
+	"center": AlignCenter,
+}
+
+// Types with a special conversion to cells
+var (
+	timeType     = reflect.TypeOf(time.Time{})                             // time.Time
+	durationType = reflect.TypeOf(time.Duration(0))                        // time.Duration
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()             // fmt.Stringer
+	textType     = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem() // encoding.TextUnmarshaler
+)
+
+// StructArgs holds the arguments for tables from slices of structs.
+//
+//	NonPrintable:	handling of non-printable runes in cells (default NonPrintableError)
+type StructArgs struct {
+	NonPrintable NonPrintable // Policy for non-printable runes
+}
+
+// A structField holds a column derived from an exported struct field.
+type structField struct {
+	index     int       // Index of the field in the struct
+	name      string    // Header name of the column
+	align     Alignment // Alignment of the column
+	format    string    // Format verb for fmt.Sprintf
+	layout    string    // Layout of time.Time fields
+	omitempty bool      // Zero values are empty cells
+}
+
+// FromStructs returns a new Table with a row for each element of s. T must be a struct or a pointer to a struct.
+// The header is derived from the exported fields of T in the order of their declaration. The tag tstable sets the
+// header name and options separated by commas, for example
+//
+//	Price float64 `tstable:"Unit price,align=right,format=%.2f,omitempty"`
+//
+// An empty name keeps the field name and the name - skips the field. Option align sets the column alignment to left,
+// right or center, format sets a format for fmt.Sprintf, layout sets the layout of time.Time fields (default RFC3339)
+// and omitempty returns zero values as empty cells. Pointers are dereferenced and nil pointers are empty cells.
+// Values of type time.Time, time.Duration and fmt.Stringer are converted with their String or Format method. Non-printable
+// runes are handled according to the NonPrintable policy in a. If a is nil, default values are used. It returns nil
+// and an error, if T is not a struct, if a tag is invalid, if T does not have exported fields or if an element of s is
+// a nil pointer.
+func FromStructs[T any](s []T, a *StructArgs) (*Table, error) {
+	// Use default arguments, if a is nil
+	if a == nil {
+		a = &StructArgs{}
+	}
+	// Retrieve columns from the exported fields of T
+	f, e := structFields(reflect.TypeOf((*T)(nil)).Elem())
+	if e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "structFields", Fn: "T", Err: e})
+	}
+	// Retrieve header names
+	h := make([]string, len(f))
+	for i, c := range f {
+		h[i] = c.name
+	}
+	// Retrieve new table with header h
+	t, e := newImport(h, a.NonPrintable)
+	if e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "newImport", Fn: "structs", Err: e})
+	}
+	// Set column alignment
+	for i, c := range f {
+		t.align[i] = c.align
+	}
+	// Add a row for each element of s
+	for i := range s {
+		v := reflect.ValueOf(&s[i]).Elem()
+		// Dereference pointers to structs
+		if v.Kind() == reflect.Pointer {
+			// Return nil and an error, if the element is a nil pointer
+			if v.IsNil() {
+				return nil, tserr.NilPtr()
+			}
+			v = v.Elem()
+		}
+		// Convert fields to cells
+		r := make([]string, len(f))
+		for j, c := range f {
+			r[j] = c.cell(v.Field(c.index))
+		}
+		// Add row to the table
+		if e := t.addImport(r, a.NonPrintable); e != nil {
+			return nil, tserr.Op(&tserr.OpArgs{Op: "addImport", Fn: "element " + strconv.Itoa(i), Err: e})
+		}
+	}
+	// Return table
+	return t, nil
+}
+
+// structFields returns the columns of struct type t or of the struct type t points to. It returns nil and an error,
+// if t is not a struct, if a tag is invalid or if t does not have exported fields.
+func structFields(t reflect.Type) ([]structField, error) {
+	// Dereference pointer type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	// Return nil and an error, if t is not a struct
+	if t.Kind() != reflect.Struct {
+		return nil, tserr.TypeNotMatching(&tserr.TypeNotMatchingArgs{Act: t.String(), Want: "struct"})
+	}
+	var f []structField
+	// Iterate fields of t
+	for i := 0; i < t.NumField(); i++ {
+		s := t.Field(i)
+		// Skip unexported fields
+		if !s.IsExported() {
+			continue
+		}
+		// Retrieve name and options from the tag
+		opts := strings.Split(s.Tag.Get(structTag), ",")
+		// Skip fields with name -
+		if (opts[0] == "-") && (len(opts) == 1) {
+			continue
+		}
+		c := structField{index: i, name: s.Name, layout: structLayout}
+		if opts[0] != "" {
+			c.name = opts[0]
+		}
+		// Evaluate options
+		for _, o := range opts[1:] {
+			k, v, _ := strings.Cut(o, "=")
+			switch k {
+			case "align":
+				a, ok := structAlign[v]
+				// Return nil and an error, if the alignment is unknown
+				if !ok {
+					return nil, tserr.NotExistent("alignment " + v + " of field " + s.Name)
+				}
+				c.align = a
+			case "format":
+				c.format = v
+			case "layout":
+				c.layout = v
+			case "omitempty":
+				c.omitempty = true
+			default:
+				// Return nil and an error, if the option is unknown
+				return nil, tserr.NotExistent("option " + k + " of field " + s.Name)
+			}
+		}
+		f = append(f, c)
+	}
+	// Return nil and an error, if t does not have exported fields
+	if len(f) == 0 {
+		return nil, tserr.Empty("exported fields of " + t.String())
+	}
+	// Return columns
+	return f, nil
+}
+
+// cell returns field value v converted to a cell according to the options of column c.
+func (c structField) cell(v reflect.Value) string {
+	// Return an empty cell for zero values, if omitempty is set
+	if c.omitempty && v.IsZero() {
+		return ""
+	}
+	// Dereference pointers and return an empty cell for nil pointers
+	for (v.Kind() == reflect.Pointer) || (v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	// Return value formatted with format, if set
+	if c.format != "" {
+		return fmt.Sprintf(c.format, v.Interface())
+	}
+	// Convert time.Time, time.Duration and fmt.Stringer with their methods
+	switch {
+	case v.Type() == timeType:
+		return v.Interface().(time.Time).Format(c.layout)
+	case v.Type() == durationType:
+		return v.Interface().(time.Duration).String()
+	case v.Type().Implements(stringerType):
+		return v.Interface().(fmt.Stringer).String()
+	case v.CanAddr() && v.Addr().Type().Implements(stringerType):
+		return v.Addr().Interface().(fmt.Stringer).String()
+	}
+	// Convert basic types
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	}
+	// Convert any other type with fmt.Sprint
+	return fmt.Sprint(v.Interface())
+}
+
+// ToStructs returns a slice with an element of type T for each row of table t. T must be a struct or a pointer to a
+// struct. The header names of t are matched to the exported fields of T by the name in the tag tstable or the field
+// name, like with FromStructs. If no name matches exactly, the names are matched case-insensitive. Columns without a
+// matching field are ignored and fields without a matching column keep their zero value. Cells are parsed according to
+// the field type: strings, bools, signed and unsigned decimal integers, floats, time.Duration, time.Time with the layout
+// of the tag (default RFC3339), types implementing encoding.TextUnmarshaler and pointers to these types. Integers are
+// always parsed in base 10, so that leading zeros like in 08 do not select another base. Empty cells result in zero
+// values and nil pointers. The rows are in the same order as with Print. It returns nil and an error, if T is not a
+// struct, if a tag is invalid or if a cell cannot be parsed.
+func ToStructs[T any](t *Table) ([]T, error) {
+	// Retrieve view of t
+	v, e := t.view()
+	if e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "view", Fn: "table", Err: e})
+	}
+	// Retrieve fields of T
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	f, e := structFields(typ)
+	if e != nil {
+		return nil, tserr.Op(&tserr.OpArgs{Op: "structFields", Fn: "T", Err: e})
+	}
+	// Match columns to fields
+	cols := structMatch(v.header, f)
+	// Allocate result
+	s := make([]T, len(v.rows))
+	for i, r := range v.rows {
+		// Retrieve struct value of element i, allocate pointers to structs
+		sv := reflect.ValueOf(&s[i]).Elem()
+		if sv.Kind() == reflect.Pointer {
+			sv.Set(reflect.New(typ.Elem()))
+			sv = sv.Elem()
+		}
+		// Parse cells into matched fields
+		for j, c := range cols {
+			if c == nil {
+				continue
+			}
+			if e := c.parse(sv.Field(c.index), r[j]); e != nil {
+				return nil, tserr.Op(&tserr.OpArgs{Op: "parse", Fn: "row " + strconv.Itoa(i) + " column " + v.header[j], Err: e})
+			}
+		}
+	}
+	// Return slice of T
+	return s, nil
+}
+
+// structMatch returns the matching field of f for each name of header h or nil, if no field matches. Names are matched
+// exactly and, if no name matches exactly, case-insensitive. A field is matched to one column at most.
+func structMatch(h []string, f []structField) []*structField {
+	cols, used := make([]*structField, len(h)), make([]bool, len(f))
+	// Match names exactly first and case-insensitive second
+	for _, eq := range []func(a, b string) bool{func(a, b string) bool { return a == b }, strings.EqualFold} {
+		for i, n := range h {
+			if cols[i] != nil {
+				continue
+			}
+			for j := range f {
+				if !used[j] && eq(n, f[j].name) {
+					cols[i], used[j] = &f[j], true
+					break
+				}
+			}
+		}
+	}
+	// Return matching fields
+	return cols
+}
+
+// parse parses cell c into field value v according to the type of v and the options of column f. It returns an
+// error, if c cannot be parsed into the type of v.
+func (f structField) parse(v reflect.Value, c string) error {
+	// Set zero value for empty cells
+	if c == "" {
+		v.SetZero()
+		return nil
+	}
+	// Allocate pointers
+	if v.Kind() == reflect.Pointer {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+	// Parse time.Time and time.Duration as well as types implementing encoding.TextUnmarshaler
+	switch {
+	case v.Type() == timeType:
+		p, e := time.Parse(f.layout, c)
+		if e != nil {
+			return tserr.Op(&tserr.OpArgs{Op: "Parse", Fn: c, Err: e})
+		}
+		v.Set(reflect.ValueOf(p))
+		return nil
+	case v.Type() == durationType:
+		p, e := time.ParseDuration(c)
+		if e != nil {
+			return tserr.Op(&tserr.OpArgs{Op: "ParseDuration", Fn: c, Err: e})
+		}
+		v.SetInt(int64(p))
+		return nil
+	case v.CanAddr() && v.Addr().Type().Implements(textType):
+		if e := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(c)); e != nil {
+			return tserr.Op(&tserr.OpArgs{Op: "UnmarshalText", Fn: c, Err: e})
+		}
+		return nil
+	}
+	// Parse basic types
+	var e error
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(c)
+	case reflect.Bool:
+		var p bool
+		if p, e = strconv.ParseBool(c); e == nil {
+			v.SetBool(p)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var p int64
+		if p, e = strconv.ParseInt(c, 10, v.Type().Bits()); e == nil {
+			v.SetInt(p)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var p uint64
+		if p, e = strconv.ParseUint(c, 10, v.Type().Bits()); e == nil {
+			v.SetUint(p)
+		}
+	case reflect.Float32, reflect.Float64:
+		var p float64
+		if p, e = strconv.ParseFloat(c, v.Type().Bits()); e == nil {
+			v.SetFloat(p)
+		}
+	default:
+		// Return an error, if the type is not supported
+		return tserr.TypeNotMatching(&tserr.TypeNotMatchingArgs{Act: v.Type().String(), Want: "supported field type"})
+	}
+	// Return an error, if parsing fails
+	if e != nil {
+		return tserr.Op(&tserr.OpArgs{Op: "parse", Fn: c, Err: e})
+	}
+	// Return nil
+	return nil
+}
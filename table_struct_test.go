@@ -0,0 +1,238 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable_test
+
+// Import Go standard library packages as well as tstable and tserr
+import (
+	"fmt"     // fmt
+	"testing" // testing
+	"time"    // time
+
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tstable" // tstable
+)
+
+// testRace implements fmt.Stringer
+type testRace int
+
+// String returns the name of race r.
+func (r testRace) String() string {
+	return [...]string{"Hobbit", "Elf"}[r]
+}
+
+// testMember is a struct with tagged fields of different types
+type testMember struct {
+	Name     string
+	Race     testRace      `tstable:",align=center"`
+	Height   float64       `tstable:"Height (m),align=right,format=%.2f"`
+	Ring     *string       `tstable:"Ring bearer"`
+	Born     time.Time     `tstable:",layout=2006-01-02"`
+	Journey  time.Duration `tstable:",omitempty"`
+	Age      *int          `tstable:",align=right"`
+	Internal string        `tstable:"-"`
+	secret   string
+}
+
+// TestFromStructs tests a table from a slice of structs. The test fails if FromStructs returns an error
+// or if the Markdown representation of the table does not match.
+func TestFromStructs(t *testing.T) {
+	ring, age := "One Ring", 50
+	m := []testMember{
+		{Name: "Frodo", Race: 0, Height: 1.065, Ring: &ring, Born: time.Date(2968, 9, 22, 0, 0, 0, 0, time.UTC), Journey: 13 * time.Hour, Age: &age, Internal: "x", secret: "y"},
+		{Name: "Legolas", Race: 1, Height: 1.83},
+	}
+	// Retrieve table from slice of structs
+	tbl, e := tstable.FromStructs(m, nil)
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "FromStructs", Fn: "testMember", Err: e}))
+	}
+	// Retrieve Markdown representation
+	s, e := tbl.Markdown()
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Markdown", Fn: "table", Err: e}))
+	}
+	// The test fails if the Markdown representation does not match
+	want := "| Name | Race | Height (m) | Ring bearer | Born | Journey | Age |\n" +
+		"| --- | :---: | ---: | --- | --- | --- | ---: |\n" +
+		"| Frodo | Hobbit | 1.06 | One Ring | 2968-09-22 | 13h0m0s | 50 |\n" +
+		"| Legolas | Elf | 1.83 |  | 0001-01-01 |  |  |\n"
+	if s != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "FromStructs", Actual: s, Want: want}))
+	}
+}
+
+// TestFromStructsPointer tests a table from a slice of pointers to structs. The test fails if FromStructs returns
+// an error for pointers or if it does not return an error for a nil pointer.
+func TestFromStructsPointer(t *testing.T) {
+	type member struct{ Name string }
+	// The test fails if FromStructs returns an error for pointers to structs
+	tbl, e := tstable.FromStructs([]*member{{Name: "Sam"}}, nil)
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "FromStructs", Fn: "member", Err: e}))
+	}
+	// The test fails if the table does not contain one row
+	if s, _ := tbl.Markdown(); s != "| Name |\n| --- |\n| Sam |\n" {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "FromStructs", Actual: s, Want: "Sam"}))
+	}
+	// The test fails if FromStructs does not return an error for a nil pointer
+	if _, e := tstable.FromStructs([]*member{nil}, nil); e == nil {
+		t.Error(tserr.NilFailed("FromStructs"))
+	}
+}
+
+// TestFromStructsNonPrintable tests the NonPrintable policy of FromStructs. The test fails if the row is not skipped.
+func TestFromStructsNonPrintable(t *testing.T) {
+	type member struct{ Name string }
+	m := []member{{Name: "Merry\n"}, {Name: "Pippin"}}
+	// The test fails if FromStructs does not return an error per default
+	if _, e := tstable.FromStructs(m, nil); e == nil {
+		t.Error(tserr.NilFailed("FromStructs"))
+	}
+	// The test fails if the row with a non-printable rune is not skipped
+	tbl, e := tstable.FromStructs(m, &tstable.StructArgs{NonPrintable: tstable.NonPrintableSkip})
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "FromStructs", Fn: "member", Err: e}))
+	}
+	if s, _ := tbl.Markdown(); s != "| Name |\n| --- |\n| Pippin |\n" {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "FromStructs", Actual: s, Want: "Pippin"}))
+	}
+}
+
+// TestFromStructsErr tests FromStructs with types which are not structs, without exported fields and with invalid tags.
+// The test fails if FromStructs does not return an error.
+func TestFromStructsErr(t *testing.T) {
+	// The test fails if FromStructs does not return an error for a type which is not a struct
+	if _, e := tstable.FromStructs([]int{1}, nil); e == nil {
+		t.Error(tserr.NilFailed("FromStructs"))
+	}
+	// The test fails if FromStructs does not return an error for a struct without exported fields
+	if _, e := tstable.FromStructs([]struct{ name string }{}, nil); e == nil {
+		t.Error(tserr.NilFailed("FromStructs"))
+	}
+	// The test fails if FromStructs does not return an error for an unknown alignment
+	if _, e := tstable.FromStructs([]struct {
+		Name string `tstable:",align=top"`
+	}{}, nil); e == nil {
+		t.Error(tserr.NilFailed("FromStructs"))
+	}
+	// The test fails if FromStructs does not return an error for an unknown option
+	if _, e := tstable.FromStructs([]struct {
+		Name string `tstable:",bold"`
+	}{}, nil); e == nil {
+		t.Error(tserr.NilFailed("FromStructs"))
+	}
+}
+
+// testRecord is a struct for decoding table rows
+type testRecord struct {
+	Host    string
+	CPU     float64       `tstable:"cpu"`
+	Cores   uint8         `tstable:"Cores"`
+	Up      bool          `tstable:"Up"`
+	Uptime  time.Duration `tstable:"Uptime"`
+	Since   time.Time     `tstable:"Since,layout=2006-01-02"`
+	Load    *int          `tstable:"Load"`
+	Ignored string        `tstable:"-"`
+}
+
+// TestToStructs tests decoding rows of a table into a slice of structs. The header names are matched case-insensitive,
+// the column Rack does not match a field and integers with leading zeros are decimal. The test fails if ToStructs returns an error or if the decoded structs
+// do not match.
+func TestToStructs(t *testing.T) {
+	// Retrieve test table
+	tbl, e := tstable.New([]string{"Host", "CPU", "Cores", "Up", "Uptime", "Since", "Load", "Rack"})
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "New", Fn: "table", Err: e}))
+	}
+	// Add rows
+	if e := tbl.AddRow([]string{"node1", "0.75", "08", "true", "1h30m", "2023-05-01", "010", "A"}); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: "table", Err: e}))
+	}
+	if e := tbl.AddRow([]string{"node2", "", "16", "false", "", "", "", "B"}); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: "table", Err: e}))
+	}
+	// Decode rows
+	r, e := tstable.ToStructs[testRecord](tbl)
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ToStructs", Fn: "testRecord", Err: e}))
+	}
+	// The test fails if the decoded structs do not match
+	if len(r) != 2 {
+		t.Fatal(tserr.Equal(&tserr.EqualArgs{Var: "records", Actual: int64(len(r)), Want: 2}))
+	}
+	want := testRecord{Host: "node1", CPU: 0.75, Cores: 8, Up: true, Uptime: 90 * time.Minute, Since: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)}
+	if (r[0].Load == nil) || (*r[0].Load != 10) {
+		t.Error(tserr.NilPtr())
+	}
+	r[0].Load = nil
+	if r[0] != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "record", Actual: fmt.Sprint(r[0]), Want: fmt.Sprint(want)}))
+	}
+	want = testRecord{Host: "node2", Cores: 16}
+	if r[1] != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "record", Actual: fmt.Sprint(r[1]), Want: fmt.Sprint(want)}))
+	}
+}
+
+// TestToStructsRoundTrip tests decoding a table retrieved with FromStructs into pointers to structs. The test fails if
+// the decoded structs do not equal the original structs.
+func TestToStructsRoundTrip(t *testing.T) {
+	type member struct {
+		Name   string
+		Height float64 `tstable:"Height (m)"`
+	}
+	m := []member{{"Frodo", 1.06}, {"Sam", 1.07}}
+	// Retrieve table from structs
+	tbl, e := tstable.FromStructs(m, nil)
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "FromStructs", Fn: "member", Err: e}))
+	}
+	// Decode table into pointers to structs
+	p, e := tstable.ToStructs[*member](tbl)
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ToStructs", Fn: "member", Err: e}))
+	}
+	// The test fails if the decoded structs do not equal the original structs
+	for i := range m {
+		if *p[i] != m[i] {
+			t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "member", Actual: fmt.Sprint(*p[i]), Want: fmt.Sprint(m[i])}))
+		}
+	}
+}
+
+// TestToStructsErr tests ToStructs with cells which cannot be parsed, a type which is not a struct and a nil table.
+// The test fails if ToStructs does not return an error.
+func TestToStructsErr(t *testing.T) {
+	h := []string{"Cores", "Up", "Uptime", "Since"}
+	// Test a table with one row with cell c in column i, which cannot be parsed
+	for i, c := range []string{"0x8", "yes", "forever", "yesterday"} {
+		// Retrieve test table
+		tbl, e := tstable.New(h)
+		if e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "New", Fn: "table", Err: e}))
+		}
+		r := make([]string, len(h))
+		r[i] = c
+		if e := tbl.AddRow(r); e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: "table", Err: e}))
+		}
+		// The test fails if ToStructs does not return an error for cell c
+		if _, e := tstable.ToStructs[testRecord](tbl); e == nil {
+			t.Error(tserr.NilFailed("ToStructs"))
+		}
+	}
+	// Retrieve test table
+	tbl, e := tstable.New(h)
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "New", Fn: "table", Err: e}))
+	}
+	// The test fails if ToStructs does not return an error for a type which is not a struct
+	if _, e := tstable.ToStructs[string](tbl); e == nil {
+		t.Error(tserr.NilFailed("ToStructs"))
+	}
+	// The test fails if ToStructs does not return an error for a nil table
+	if _, e := tstable.ToStructs[testRecord](nil); e == nil {
+		t.Error(tserr.NilFailed("ToStructs"))
+	}
+}
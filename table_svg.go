@@ -0,0 +1,193 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable
+
+// Import Go standard packages and tserr
+import (
+	"encoding/xml" // xml
+	"math"         // math
+	"strconv"      // strconv
+	"strings"      // strings
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// Default values and ratios for the SVG representation of a table
+const (
+	svgFontSize   float64 = 14          // Default font size
+	svgFontFamily string  = "monospace" // Default font family
+	svgColor      string  = "black"     // Default color of text and grid lines
+	svgBackground string  = "white"     // Default background color
+	svgCharWidth  float64 = 0.6         // Width of a monospaced character relative to the font size
+	svgLineHeight float64 = 1.6         // Height of a row relative to the font size
+	svgBaseline   float64 = 1.1         // Baseline of text in a row relative to the font size
+)
+
+// svgWidth maps the weight of a grid line to its stroke width
+var svgWidth = map[int]float64{
+	lineLight:  1, // Stroke width of light grid lines
+	lineBold:   2, // Stroke width of bold grid lines
+	lineDouble: 3, // Stroke width of double grid lines
+}
+
+// svgDash maps the number of dashes of a grid line to its stroke dash array
+var svgDash = map[int]string{
+	2: "6 3", // Double dash lines
+	3: "4 2", // Triple dash lines
+	4: "1 2", // Quadruple dash lines
+}
+
+// SVGArgs holds the arguments for the SVG representation of a table. Empty fields are set to default values.
+//
+//	FontSize:	font size in pixels (default 14)
+//	FontFamily:	monospaced font family (default monospace)
+//	Color:		color of text and grid lines (default black)
+//	Background:	background color, none for a transparent background (default white)
+type SVGArgs struct {
+	FontSize   float64 // Font size
+	FontFamily string  // Font family
+	Color      string  // Color of text and grid lines
+	Background string  // Background color
+}
+
+// SVG returns table t as a standalone SVG document. The text is positioned on a grid computed from the
+// column widths and the padding with a monospaced font. The grid lines are drawn as defined by the Grid of t.
+// Light grid lines have stroke width 1, bold grid lines have stroke width 2 and double grid lines have stroke
+// width 3. Dashed and dotted grid lines are drawn with a dash array. The header is bold and cells are
+// positioned according to the column alignment. If a is nil, default values are used. The rows are sorted
+// in the same order as with Print. It returns an empty string and an error, if any.
+func (t *Table) SVG(a *SVGArgs) (string, error) {
+	// Retrieve view of t
+	v, e := t.view()
+	// Return an empty string and an error if view fails
+	if e != nil {
+		return "", tserr.Op(&tserr.OpArgs{Op: "view", Fn: "table", Err: e})
+	}
+	// Return SVG representation
+	return v.svg(a)
+}
+
+// svg returns view v as a standalone SVG document.
+func (v *View) svg(a *SVGArgs) (string, error) {
+	// Use default arguments, if a is nil
+	if a == nil {
+		a = &SVGArgs{}
+	}
+	// Return an empty string and an error if the font size is negative
+	if a.FontSize < 0 {
+		return "", tserr.Higher(&tserr.HigherArgs{Var: "font size", Actual: int64(a.FontSize), LowerBound: 0})
+	}
+	// Set default values for empty arguments
+	fs, ff, fc, bg := a.FontSize, a.FontFamily, a.Color, a.Background
+	if fs == 0 {
+		fs = svgFontSize
+	}
+	if ff == "" {
+		ff = svgFontFamily
+	}
+	if fc == "" {
+		fc = svgColor
+	}
+	if bg == "" {
+		bg = svgBackground
+	}
+	// Character width, row height and margin
+	cw, lh := fs*svgCharWidth, fs*svgLineHeight
+	m := cw
+	// Compute the x positions of vertical grid lines
+	x := make([]float64, len(v.width)+1)
+	x[0] = m
+	for i, w := range v.width {
+		x[i+1] = x[i] + float64(w+2*v.padding)*cw
+	}
+	// Compute the y positions of horizontal lines at the top of each row and at the bottom of the table
+	y := make([]float64, len(v.rows)+2)
+	for i := range y {
+		y[i] = m + float64(i)*lh
+	}
+	// Width and height of the SVG document
+	w, h := x[len(x)-1]+m, y[len(y)-1]+m
+	var b strings.Builder
+	// Write SVG document start and background
+	b.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" width="` + svgNum(w) + `" height="` + svgNum(h) +
+		`" viewBox="0 0 ` + svgNum(w) + " " + svgNum(h) + `" font-family="` + xmlEscape(ff) + `" font-size="` + svgNum(fs) + `">` + "\n")
+	if bg != "none" {
+		b.WriteString(`<rect width="100%" height="100%" fill="` + xmlEscape(bg) + `"/>` + "\n")
+	}
+	// Write grid lines
+	b.WriteString(`<g stroke="` + xmlEscape(fc) + `" stroke-linecap="square">` + "\n")
+	top, bottom := y[0], y[len(y)-1]
+	svgLine(&b, v.grid.Hb, x[0], top, x[len(x)-1], top)
+	svgLine(&b, v.grid.Hi, x[0], y[1], x[len(x)-1], y[1])
+	svgLine(&b, v.grid.Hb, x[0], bottom, x[len(x)-1], bottom)
+	for i, p := range x {
+		g := v.grid.Vi
+		if (i == 0) || (i == len(x)-1) {
+			g = v.grid.Vb
+		}
+		svgLine(&b, g, p, top, p, bottom)
+	}
+	b.WriteString("</g>\n")
+	// Write header and rows
+	b.WriteString(`<g fill="` + xmlEscape(fc) + `" xml:space="preserve">` + "\n")
+	v.svgRow(&b, v.header, x, y[0]+fs*svgBaseline, cw, ` font-weight="bold"`)
+	for i, r := range v.rows {
+		v.svgRow(&b, r, x, y[i+1]+fs*svgBaseline, cw, "")
+	}
+	b.WriteString("</g>\n</svg>\n")
+	// Return SVG representation
+	return b.String(), nil
+}
+
+// svgRow writes the cells of row r as text elements with baseline y to b. The cells are positioned according to the
+// column alignment within the vertical grid lines x with padding in units of character width cw. The attributes attr
+// are added to each text element.
+func (v *View) svgRow(b *strings.Builder, r []string, x []float64, y, cw float64, attr string) {
+	p := float64(v.padding) * cw
+	for i, c := range r {
+		// Skip empty cells
+		if c == "" {
+			continue
+		}
+		// Position text according to the column alignment
+		tx, anchor := x[i]+p, ""
+		switch v.Alignment(i) {
+		case AlignRight:
+			tx, anchor = x[i+1]-p, ` text-anchor="end"`
+		case AlignCenter:
+			tx, anchor = (x[i]+x[i+1])/2, ` text-anchor="middle"`
+		}
+		b.WriteString(`<text x="` + svgNum(tx) + `" y="` + svgNum(y) + `"` + anchor + attr + ">" + xmlEscape(c) + "</text>\n")
+	}
+}
+
+// svgLine writes a line from (x1, y1) to (x2, y2) to b with the stroke defined by grid rune g. It does not write
+// a line if g is empty or non-printable.
+func svgLine(b *strings.Builder, g rune, x1, y1, x2, y2 float64) {
+	// Retrieve the line of g
+	l := gridLineOf(g)
+	if l.weight == lineNone {
+		return
+	}
+	// Write line with the stroke width of its weight and the dash array of its dashes
+	b.WriteString(`<line x1="` + svgNum(x1) + `" y1="` + svgNum(y1) + `" x2="` + svgNum(x2) + `" y2="` + svgNum(y2) +
+		`" stroke-width="` + svgNum(svgWidth[l.weight]) + `"`)
+	if d, ok := svgDash[l.dashes]; ok {
+		b.WriteString(` stroke-dasharray="` + d + `"`)
+	}
+	b.WriteString("/>\n")
+}
+
+// svgNum returns f rounded to two decimal places as a string.
+func svgNum(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
+
+// xmlEscape returns s with XML special characters escaped.
+func xmlEscape(s string) string {
+	var b strings.Builder
+	// Escaping into a strings.Builder cannot fail
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
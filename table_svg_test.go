@@ -0,0 +1,65 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable_test
+
+// Import Go standard library packages as well as tstable and tserr
+import (
+	"encoding/xml" // xml
+	"errors"       // errors
+	"io"           // io
+	"strings"      // strings
+	"testing"      // testing
+
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tstable" // tstable
+)
+
+// TestSVG tests the SVG representations of the test table with aligned columns for grids with light, double, bold,
+// dashed and empty grid lines.
+// The test fails if an SVG representation is not well-formed XML or if it does not equal to the
+// contents of the test data golden file.
+func TestSVG(t *testing.T) {
+	// Retrieve test table with aligned columns
+	tbl := testAlignedTable(t)
+	// Iterate over grids
+	for _, name := range []string{"SimpleGrid", "DoubleBorderGrid", "BoldGrid", "DashedGrid", "EmptyGrid"} {
+		// Retrieve Grid for name
+		grid, ok := tstable.AllGrids[name]
+		// The test fails if Grid is not found
+		if !ok {
+			t.Fatal(tserr.NotExistent(name))
+		}
+		// Set the grid of the test table
+		if e := tbl.SetGrid(grid); e != nil {
+			t.Error(tserr.Op(&tserr.OpArgs{Op: "SetGrid", Fn: name, Err: e}))
+		}
+		// Retrieve SVG representation
+		s, e := tbl.SVG(nil)
+		// The test fails if SVG returns an error
+		if e != nil {
+			t.Error(tserr.Op(&tserr.OpArgs{Op: "SVG", Fn: name, Err: e}))
+		}
+		// The test fails if the SVG representation is not well-formed XML
+		d := xml.NewDecoder(strings.NewReader(s))
+		for {
+			if _, e := d.Token(); e != nil {
+				if !errors.Is(e, io.EOF) {
+					t.Error(tserr.Op(&tserr.OpArgs{Op: "Token", Fn: name, Err: e}))
+				}
+				break
+			}
+		}
+		// Evaluate the SVG representation
+		evalGolden("SVG"+name, s, t)
+	}
+}
+
+// TestSVGErr tests SVG to return an error in case the font size is negative.
+// The test fails if SVG returns a nil error.
+func TestSVGErr(t *testing.T) {
+	// The test fails if SVG returns a nil error
+	if _, e := testTable(t).SVG(&tstable.SVGArgs{FontSize: -1}); e == nil {
+		t.Error(tserr.NilFailed("SVG"))
+	}
+}
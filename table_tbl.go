@@ -0,0 +1,132 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable
+
+// Import Go standard package strings and tserr
+import (
+	"strings" // strings
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// TblArgs holds the arguments for the groff tbl representation of a table.
+//
+//	AllBox:	enclose each cell in a box, independent of the table grid
+type TblArgs struct {
+	AllBox bool // Enclose each cell in a box
+}
+
+// tblSpec maps a column alignment to its key letter in a tbl format line
+var tblSpec = map[Alignment]string{
+	AlignLeft:   "l",
+	AlignRight:  "r",
+	AlignCenter: "c",
+}
+
+// Tbl returns table t as a groff tbl preprocessor block enclosed in .TS and .TE. The format lines are derived
+// from the column alignment and the header is bold. The options are mapped from the Grid of t: a border results in
+// option box or doublebox for double-lined borders, inside vertical grid lines separate the columns in the format
+// lines and the inside horizontal grid line is drawn below the header, doubled for double-lined grids. If AllBox
+// is set in a, option allbox is used instead. Cells are escaped for groff. The rows are sorted in the same order as
+// with Print. It returns an empty string and an error, if any.
+func (t *Table) Tbl(a *TblArgs) (string, error) {
+	// Retrieve view of t
+	v, e := t.view()
+	// Return an empty string and an error if view fails
+	if e != nil {
+		return "", tserr.Op(&tserr.OpArgs{Op: "view", Fn: "table", Err: e})
+	}
+	// Return tbl representation
+	return v.tbl(a)
+}
+
+// tbl returns view v as a groff tbl preprocessor block.
+func (v *View) tbl(a *TblArgs) (string, error) {
+	// Use default arguments, if a is nil
+	if a == nil {
+		a = &TblArgs{}
+	}
+	// Map grid border to options
+	opt := ""
+	switch {
+	case a.AllBox:
+		opt = "allbox"
+	case tblDouble(v.grid.Hb) || tblDouble(v.grid.Vb):
+		opt = "doublebox"
+	case tblLine(v.grid.Hb) || tblLine(v.grid.Vb):
+		opt = "box"
+	}
+	// Map inside vertical grid line to column separators
+	sep := " "
+	if !a.AllBox && tblLine(v.grid.Vi) {
+		sep = " | "
+		if tblDouble(v.grid.Vi) {
+			sep = " || "
+		}
+	}
+	// Derive format lines for header and rows from column alignment
+	hf, rf := make([]string, len(v.header)), make([]string, len(v.header))
+	for i := range v.header {
+		hf[i], rf[i] = tblSpec[v.Alignment(i)]+"B", tblSpec[v.Alignment(i)]
+	}
+	var b strings.Builder
+	// Write table start, options and format lines
+	b.WriteString(".TS\n")
+	if opt != "" {
+		b.WriteString(opt + ";\n")
+	}
+	b.WriteString(strings.Join(hf, sep) + "\n" + strings.Join(rf, sep) + ".\n")
+	// Write header
+	tblRow(&b, v.header)
+	// Write horizontal line below header
+	if !a.AllBox && tblLine(v.grid.Hi) {
+		if tblDouble(v.grid.Hi) {
+			b.WriteString("=\n")
+		} else {
+			b.WriteString("_\n")
+		}
+	}
+	// Write rows
+	for _, r := range v.rows {
+		tblRow(&b, r)
+	}
+	// Write table end
+	b.WriteString(".TE\n")
+	// Return tbl representation
+	return b.String(), nil
+}
+
+// tblRow writes row r with escaped cells separated by tabs to b.
+func tblRow(b *strings.Builder, r []string) {
+	for i, c := range r {
+		if i > 0 {
+			b.WriteByte('\t')
+		}
+		b.WriteString(tblEscape(c))
+	}
+	b.WriteString("\n")
+}
+
+// tblEscape returns cell c escaped for groff. Backslashes are escaped and cells, which would be interpreted as
+// requests, horizontal lines or text blocks, are prefixed with the zero-width character \&.
+func tblEscape(c string) string {
+	// Escape backslashes
+	c = strings.ReplaceAll(c, `\`, `\e`)
+	// Prefix cells with special meaning with a zero-width character
+	if strings.HasPrefix(c, ".") || strings.HasPrefix(c, "'") || (c == "_") || (c == "=") || strings.HasPrefix(c, "T{") {
+		return `\&` + c
+	}
+	// Return c
+	return c
+}
+
+// tblLine returns true, if grid rune g is a visible line.
+func tblLine(g rune) bool {
+	return gridLineOf(g).weight != lineNone
+}
+
+// tblDouble returns true, if grid rune g is a double line.
+func tblDouble(g rune) bool {
+	return gridLineOf(g).weight == lineDouble
+}
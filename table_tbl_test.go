@@ -0,0 +1,71 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable_test
+
+// Import Go standard library package testing as well as tstable and tserr
+import (
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tstable" // tstable
+)
+
+// TestTbl tests the groff tbl representations of the test table with aligned columns for grids with light,
+// double and empty grid lines and with option allbox. The test fails if a tbl representation does not
+// equal to the contents of the test data golden file.
+func TestTbl(t *testing.T) {
+	// Test cases with golden file names, grids and tbl arguments
+	tc := map[string]struct {
+		grid *tstable.Grid
+		a    *tstable.TblArgs
+	}{
+		"TblSimpleGrid":       {&tstable.SimpleGrid, nil},
+		"TblDoubleGrid":       {&tstable.DoubleGrid, nil},
+		"TblEmptyGrid":        {&tstable.EmptyGrid, nil},
+		"TblDoubleBorderGrid": {&tstable.DoubleBorderGrid, nil},
+		"TblAllBox":           {&tstable.SimpleGrid, &tstable.TblArgs{AllBox: true}},
+	}
+	// Retrieve test table with aligned columns
+	tbl := testAlignedTable(t)
+	// Iterate all test cases
+	for name, c := range tc {
+		// Set the grid of the test table
+		if e := tbl.SetGrid(c.grid); e != nil {
+			t.Error(tserr.Op(&tserr.OpArgs{Op: "SetGrid", Fn: name, Err: e}))
+		}
+		// Retrieve tbl representation
+		s, e := tbl.Tbl(c.a)
+		// The test fails if Tbl returns an error
+		if e != nil {
+			t.Error(tserr.Op(&tserr.OpArgs{Op: "Tbl", Fn: name, Err: e}))
+		}
+		// Evaluate the tbl representation
+		evalGolden(name, s, t)
+	}
+}
+
+// TestTblEscape tests escaping of cells for groff. The test fails if the escaped cells do not match.
+func TestTblEscape(t *testing.T) {
+	// Retrieve new test table
+	tbl, e := tstable.New([]string{".Option", `C:\bin`, "_"})
+	// The test fails if New returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "New", Fn: "table", Err: e}))
+	}
+	// Set the empty grid
+	if e := tbl.SetGrid(&tstable.EmptyGrid); e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "SetGrid", Fn: "table", Err: e}))
+	}
+	// Retrieve tbl representation
+	s, e := tbl.Tbl(nil)
+	// The test fails if Tbl returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Tbl", Fn: "table", Err: e}))
+	}
+	// The test fails if the tbl representation does not match
+	want := ".TS\nlB lB lB\nl l l.\n\\&.Option\tC:\\ebin\t\\&_\n.TE\n"
+	if s != want {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "Tbl", Actual: s, Want: want}))
+	}
+}
@@ -5,7 +5,10 @@ package tstable_test
 
 // Import Go standard library packages as well as tstable, tsfio and tserr
 import (
+	"bytes"   // bytes
+	"errors"  // errors
 	"fmt"     // fmt
+	"io"      // io
 	"testing" // testing
 
 	"github.com/thorstenrie/tserr"   // tserr
@@ -256,3 +259,110 @@ func TestNilGrid(t *testing.T) {
 		t.Error(tserr.NilFailed("SetGrid"))
 	}
 }
+
+// TestAlignment tests the string representation of the test table with a right-aligned and a centered column.
+// The test fails if the retrieved string does not equal to the testdata golden file.
+func TestAlignment(t *testing.T) {
+	// Evaluate test table with aligned columns
+	evalTable("Aligned", testAlignedTable(t), t)
+}
+
+// TestAlignmentErr tests SetAlignment to return an error in case the column does not exist or the
+// alignment is invalid. The test fails if SetAlignment returns a nil error.
+func TestAlignmentErr(t *testing.T) {
+	// Retrieve test table
+	tbl := testTable(t)
+	// The test fails if SetAlignment returns a nil error for a column which does not exist
+	if e := tbl.SetAlignment("Date of Birth", tstable.AlignRight); e == nil {
+		t.Error(tserr.NilFailed("SetAlignment"))
+	}
+	// The test fails if SetAlignment returns a nil error for an invalid alignment
+	if e := tbl.SetAlignment("Title", tstable.Alignment(-1)); e == nil {
+		t.Error(tserr.NilFailed("SetAlignment"))
+	}
+}
+
+// errWriter is an io.Writer which fails after n bytes have been written
+type errWriter struct {
+	n int // Remaining number of bytes before the writer fails
+}
+
+// errWrite is the error returned by errWriter
+var errWrite = errors.New("write failed")
+
+// Write writes p to w. It returns an error, if the remaining number of bytes is exceeded.
+func (w *errWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		n := w.n
+		w.n = 0
+		return n, errWrite
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+// TestWriteTo tests writing the test table with WriteTo and Fprint. The test fails if the written table
+// does not equal the string representation of Print or if the number of written bytes does not match.
+func TestWriteTo(t *testing.T) {
+	// Retrieve test table
+	tbl := testTable(t)
+	// Retrieve string representation of test table
+	s, e := tbl.Print()
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Print", Fn: "table", Err: e}))
+	}
+	// Write test table with WriteTo
+	var b bytes.Buffer
+	n, e := tbl.WriteTo(&b)
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "WriteTo", Fn: "table", Err: e}))
+	}
+	// The test fails if the written table does not equal the string representation
+	if b.String() != s {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "WriteTo", Actual: b.String(), Want: s}))
+	}
+	// The test fails if the number of written bytes does not match
+	if n != int64(len(s)) {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "bytes written", Actual: n, Want: int64(len(s))}))
+	}
+	// Write test table with Fprint
+	b.Reset()
+	m, e := tstable.Fprint(&b, tbl)
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Fprint", Fn: "table", Err: e}))
+	}
+	// The test fails if the written table does not equal the string representation
+	if (b.String() != s) || (m != len(s)) {
+		t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "Fprint", Actual: b.String(), Want: s}))
+	}
+}
+
+// TestWriteToErr tests WriteTo with a failing writer, a nil writer and a nil table. The test fails if WriteTo
+// does not return an error, if the error of the writer is not wrapped or if the number of written bytes does not match.
+func TestWriteToErr(t *testing.T) {
+	// Retrieve test table
+	tbl := testTable(t)
+	// Write test table to a writer failing after 10 bytes
+	n, e := tbl.WriteTo(&errWriter{n: 10})
+	// The test fails if WriteTo does not return the wrapped error of the writer
+	if !errors.Is(e, errWrite) {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "WriteTo", Fn: "table", Err: e}))
+	}
+	// The test fails if the number of written bytes does not match
+	if n != 10 {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "bytes written", Actual: n, Want: 10}))
+	}
+	// The test fails if Fprint does not return the wrapped error of the writer
+	if _, e := tstable.Fprint(&errWriter{}, tbl); !errors.Is(e, errWrite) {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "Fprint", Fn: "table", Err: e}))
+	}
+	// The test fails if WriteTo does not return an error for a nil writer
+	if _, e := tbl.WriteTo(nil); e == nil {
+		t.Error(tserr.NilFailed("WriteTo"))
+	}
+	// The test fails if WriteTo does not return an error for a nil table
+	var nt *tstable.Table
+	if _, e := nt.WriteTo(io.Discard); e == nil {
+		t.Error(tserr.NilFailed("WriteTo"))
+	}
+}
@@ -70,6 +70,27 @@ func testTable(t *testing.T) *tstable.Table {
 	return tbl
 }
 
+// testAlignedTable creates the test table with a right-aligned column Title and a centered column Weapon
+// and returns a pointer to the test table.
+func testAlignedTable(t *testing.T) *tstable.Table {
+	// Panic if t is nil
+	if t == nil {
+		panic(tserr.NilPtr())
+	}
+	// Retrieve test table
+	tbl := testTable(t)
+	// Set column Title to be right-aligned
+	if e := tbl.SetAlignment("Title", tstable.AlignRight); e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "SetAlignment", Fn: "Title", Err: e}))
+	}
+	// Set column Weapon to be centered
+	if e := tbl.SetAlignment("Weapon", tstable.AlignCenter); e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "SetAlignment", Fn: "Weapon", Err: e}))
+	}
+	// Return the test table
+	return tbl
+}
+
 // evalTable evaluates a table if it equals the test data from the golden file provided by the table name. The test fails
 // if the string representation of the table does not equal to the contents of the golden file.
 func evalTable(name string, tbl *tstable.Table, t *testing.T) {
@@ -83,14 +104,25 @@ func evalTable(name string, tbl *tstable.Table, t *testing.T) {
 	if e != nil {
 		t.Error(tserr.Op(&tserr.OpArgs{Op: "Print", Fn: name, Err: e}))
 	}
+	// Evaluate the string representation of the test table
+	evalGolden(name, s, t)
+}
+
+// evalGolden evaluates string s if it equals the test data from the golden file provided by name. The test fails
+// if s does not equal to the contents of the golden file.
+func evalGolden(name, s string, t *testing.T) {
+	// Panic if t is nil
+	if t == nil {
+		panic(tserr.NilPtr())
+	}
 	/*
-		if e = tsfio.CreateGoldenFile(&tsfio.Testcase{Name: name, Data: s}); e != nil {
+		if e := tsfio.CreateGoldenFile(&tsfio.Testcase{Name: name, Data: s}); e != nil {
 			t.Error(tserr.Op(&tserr.OpArgs{Op: "CreateGoldenFile", Fn: name, Err: e}))
 		}
 	*/
 	// Retrieve the test data golden file contents for the grid
-	e = tsfio.EvalGoldenFile(&tsfio.Testcase{Name: name, Data: s})
-	// The test fails if the retrieved string representation of the test table does not equal to the contents of the test data golden file
+	e := tsfio.EvalGoldenFile(&tsfio.Testcase{Name: name, Data: s})
+	// The test fails if s does not equal to the contents of the test data golden file
 	if e != nil {
 		t.Error(tserr.Op(&tserr.OpArgs{Op: "EvalGoldenFile", Fn: name, Err: e}))
 	}
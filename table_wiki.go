@@ -0,0 +1,143 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable
+
+// Import Go standard package strings and tserr
+import (
+	"strings" // strings
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// jiraEscaper escapes the markup characters of Jira and Confluence wiki markup in cells. A backslash is written as
+// the entity &#92;, because a doubled backslash is a forced line break.
+var jiraEscaper = strings.NewReplacer(
+	`\`, `&#92;`,
+	`|`, `\|`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`*`, `\*`,
+	`_`, `\_`,
+	`+`, `\+`,
+	`^`, `\^`,
+	`~`, `\~`,
+	`?`, `\?`,
+	`-`, `\-`,
+	`!`, `\!`,
+	`#`, `\#`,
+)
+
+// mediawikiEscaper escapes HTML special characters in cells
+var mediawikiEscaper = strings.NewReplacer(
+	`&`, `&amp;`,
+	`<`, `&lt;`,
+	`>`, `&gt;`,
+)
+
+// mediawikiStyle maps a column alignment to the cell attributes in MediaWiki markup
+var mediawikiStyle = map[Alignment]string{
+	AlignLeft:   "",
+	AlignRight:  `style="text-align:right;" | `,
+	AlignCenter: `style="text-align:center;" | `,
+}
+
+// Jira returns table t in Jira and Confluence wiki markup with the header cells delimited by || and the
+// cells of rows delimited by |. Markup characters in cells are escaped with a backslash and backslashes are written
+// as the entity &#92;. The rows are sorted in the same order as with Print. It returns an empty string and an error, if any.
+func (t *Table) Jira() (string, error) {
+	// Retrieve view of t
+	v, e := t.view()
+	// Return an empty string and an error if view fails
+	if e != nil {
+		return "", tserr.Op(&tserr.OpArgs{Op: "view", Fn: "table", Err: e})
+	}
+	// Return Jira representation
+	return v.jira()
+}
+
+// jira returns view v in Jira and Confluence wiki markup.
+func (v *View) jira() (string, error) {
+	var b strings.Builder
+	// Write header
+	jiraRow(&b, v.header, "||")
+	// Write rows
+	for _, r := range v.rows {
+		jiraRow(&b, r, "|")
+	}
+	// Return Jira representation
+	return b.String(), nil
+}
+
+// jiraRow writes row r with escaped cells delimited by d to b.
+func jiraRow(b *strings.Builder, r []string, d string) {
+	b.WriteString(d)
+	for _, c := range r {
+		// An empty cell is written as a space, otherwise the delimiters would merge
+		if c == "" {
+			c = " "
+		}
+		b.WriteString(jiraEscaper.Replace(c) + d)
+	}
+	b.WriteString("\n")
+}
+
+// MediaWiki returns table t in MediaWiki markup as a table with class wikitable. Non-left-aligned columns
+// are styled with text-align. HTML special characters in cells are escaped and cells containing
+// markup characters are enclosed in nowiki tags. The rows are sorted in the same order as with Print.
+// It returns an empty string and an error, if any.
+func (t *Table) MediaWiki() (string, error) {
+	// Retrieve view of t
+	v, e := t.view()
+	// Return an empty string and an error if view fails
+	if e != nil {
+		return "", tserr.Op(&tserr.OpArgs{Op: "view", Fn: "table", Err: e})
+	}
+	// Return MediaWiki representation
+	return v.mediawiki()
+}
+
+// mediawiki returns view v in MediaWiki markup.
+func (v *View) mediawiki() (string, error) {
+	var b strings.Builder
+	// Write table start
+	b.WriteString(`{| class="wikitable"` + "\n")
+	// Write header
+	v.mediawikiRow(&b, v.header, "!")
+	// Write rows, each preceded by a row separator
+	for _, r := range v.rows {
+		b.WriteString("|-\n")
+		v.mediawikiRow(&b, r, "|")
+	}
+	// Write table end
+	b.WriteString("|}\n")
+	// Return MediaWiki representation
+	return b.String(), nil
+}
+
+// mediawikiRow writes row r with escaped and styled cells delimited by d to b.
+func (v *View) mediawikiRow(b *strings.Builder, r []string, d string) {
+	b.WriteString(d + " ")
+	for i, c := range r {
+		if i > 0 {
+			b.WriteString(" " + d + d + " ")
+		}
+		b.WriteString(mediawikiStyle[v.Alignment(i)] + mediawikiCell(c))
+	}
+	b.WriteString("\n")
+}
+
+// mediawikiCell returns cell c with escaped HTML special characters. If c contains markup characters,
+// it is enclosed in nowiki tags.
+func mediawikiCell(c string) string {
+	// Escape HTML special characters
+	c = mediawikiEscaper.Replace(c)
+	// Enclose c in nowiki tags, if it contains markup characters
+	if strings.ContainsAny(c, "|!{}[]'~=") || strings.ContainsAny(c[:min(len(c), 1)], "*#:;-+") {
+		return "<nowiki>" + c + "</nowiki>"
+	}
+	// Return c
+	return c
+}
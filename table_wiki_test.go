@@ -0,0 +1,75 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable_test
+
+// Import Go standard library package testing as well as tstable and tserr
+import (
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tstable" // tstable
+)
+
+// TestWiki tests the Jira and MediaWiki representations of the test table with a right-aligned and a centered column.
+// The test fails if a representation does not equal to the contents of the test data golden file.
+func TestWiki(t *testing.T) {
+	// Test cases with golden file names and wiki markup functions
+	tc := map[string]func(*tstable.Table) (string, error){
+		"Jira":      (*tstable.Table).Jira,
+		"MediaWiki": (*tstable.Table).MediaWiki,
+	}
+	// Retrieve test table with aligned columns
+	tbl := testAlignedTable(t)
+	// Iterate all test cases
+	for name, f := range tc {
+		// Retrieve wiki markup representation
+		s, e := f(tbl)
+		// The test fails if the wiki markup function returns an error
+		if e != nil {
+			t.Error(tserr.Op(&tserr.OpArgs{Op: name, Fn: "table", Err: e}))
+		}
+		// Evaluate the wiki markup representation
+		evalGolden(name, s, t)
+	}
+}
+
+// TestWikiEscape tests escaping of markup characters and backslashes in Jira and MediaWiki representations. The test
+// fails if the escaped representations do not match.
+func TestWikiEscape(t *testing.T) {
+	// Retrieve new test table
+	tbl, e := tstable.New([]string{"a|b", "<c>"})
+	// The test fails if New returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "New", Fn: "table", Err: e}))
+	}
+	// Add a row with markup characters and an empty cell
+	if e := tbl.AddRow([]string{"*x* [y]", ""}); e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: "table", Err: e}))
+	}
+	// Add a row with a backslash
+	if e := tbl.AddRow([]string{`C:\dir`, "z"}); e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: "table", Err: e}))
+	}
+	// Test cases with wiki markup functions and expected representations
+	tc := map[string]struct {
+		f    func(*tstable.Table) (string, error)
+		want string
+	}{
+		"Jira":      {(*tstable.Table).Jira, "||a\\|b||<c>||\n|\\*x\\* \\[y\\]| |\n|C:&#92;dir|z|\n"},
+		"MediaWiki": {(*tstable.Table).MediaWiki, "{| class=\"wikitable\"\n! <nowiki>a|b</nowiki> !! &lt;c&gt;\n|-\n| <nowiki>*x* [y]</nowiki> || \n|-\n| C:\\dir || z\n|}\n"},
+	}
+	// Iterate all test cases
+	for name, c := range tc {
+		// Retrieve wiki markup representation
+		s, e := c.f(tbl)
+		// The test fails if the wiki markup function returns an error
+		if e != nil {
+			t.Error(tserr.Op(&tserr.OpArgs{Op: name, Fn: "table", Err: e}))
+		}
+		// The test fails if the representation does not match
+		if s != c.want {
+			t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: name, Actual: s, Want: c.want}))
+		}
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable
+
+// Import Go standard packages and tserr
+import (
+	"archive/zip"  // zip
+	"io"           // io
+	"math"         // math
+	"regexp"       // regexp
+	"strconv"      // strconv
+	"strings"      // strings
+	"unicode/utf8" // utf8
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// Default sheet name and maximum length of a sheet name in a workbook
+const (
+	xlsxSheet    string = "Sheet1" // Default sheet name
+	xlsxSheetMax int    = 31       // Maximum number of runes of a sheet name
+)
+
+// xlsxNumber matches a decimal number, which is stored as a number in a workbook. The first group is the integer
+// part and the second group is the fraction of the mantissa.
+var xlsxNumber = regexp.MustCompile(`^[+-]?(?:([0-9]+)\.?([0-9]*)|\.([0-9]+))(?:[eE][+-]?[0-9]+)?$`)
+
+// xlsxDigits is the maximum number of significant digits of a number in a workbook
+const xlsxDigits int = 15
+
+// XLSXArgs holds the arguments for the Office Open XML workbook of a table.
+//
+//	Sheet:	name of the worksheet (default Sheet1)
+type XLSXArgs struct {
+	Sheet string // Name of the worksheet
+}
+
+// Static parts of the workbook
+const (
+	xlsxContentTypes = xlsxXMLHeader + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+		`</Types>`
+	xlsxRels = xlsxXMLHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbookRels = xlsxXMLHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`
+	xlsxStyles = xlsxXMLHeader + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+		`</styleSheet>`
+	xlsxXMLHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+)
+
+// XLSX writes table t as an Office Open XML workbook (.xlsx) with one worksheet to w. The header is the bold
+// and frozen first row. The column widths are derived from the column widths of t. Cells which parse as
+// decimal numbers are stored as numbers, all other cells are stored as strings. Numbers with a leading zero or
+// with more than 15 significant digits are stored as strings to keep their digits. If a is nil, the
+// worksheet is named Sheet1. The rows are sorted in the same order as with Print. It returns an error, if the
+// sheet name is invalid or if writing to w fails.
+func (t *Table) XLSX(w io.Writer, a *XLSXArgs) error {
+	// Return an error if w is nil
+	if w == nil {
+		return tserr.NilPtr()
+	}
+	// Retrieve view of t
+	v, e := t.view()
+	// Return an error if view fails
+	if e != nil {
+		return tserr.Op(&tserr.OpArgs{Op: "view", Fn: "table", Err: e})
+	}
+	// Write workbook
+	return v.xlsx(w, a)
+}
+
+// xlsx writes view v as an Office Open XML workbook to w.
+func (v *View) xlsx(w io.Writer, a *XLSXArgs) error {
+	// Use default arguments, if a is nil
+	if a == nil {
+		a = &XLSXArgs{}
+	}
+	// Set default sheet name
+	sheet := a.Sheet
+	if sheet == "" {
+		sheet = xlsxSheet
+	}
+	// Return an error if the sheet name is too long
+	if n := utf8.RuneCountInString(sheet); n > xlsxSheetMax {
+		return tserr.Lower(&tserr.LowerArgs{Var: "length of sheet name", Actual: int64(n), HigherBound: int64(xlsxSheetMax) + 1})
+	}
+	// Return an error if the sheet name contains forbidden characters
+	if strings.ContainsAny(sheet, `[]:*?/\`) {
+		return tserr.Forbidden("sheet name " + sheet)
+	}
+	// Workbook parts in order
+	parts := []struct{ name, data string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", xlsxXMLHeader + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="` + xmlEscape(sheet) + `" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+		{"xl/worksheets/sheet1.xml", v.xlsxSheet()},
+	}
+	// Write parts to the zip archive
+	z := zip.NewWriter(w)
+	for _, p := range parts {
+		f, e := z.Create(p.name)
+		if e != nil {
+			return tserr.Op(&tserr.OpArgs{Op: "Create", Fn: p.name, Err: e})
+		}
+		if _, e := io.WriteString(f, p.data); e != nil {
+			return tserr.Op(&tserr.OpArgs{Op: "WriteString", Fn: p.name, Err: e})
+		}
+	}
+	// Close the zip archive
+	if e := z.Close(); e != nil {
+		return tserr.Op(&tserr.OpArgs{Op: "Close", Fn: "workbook", Err: e})
+	}
+	// Return nil
+	return nil
+}
+
+// xlsxSheet returns the worksheet part of view v.
+func (v *View) xlsxSheet() string {
+	var b strings.Builder
+	// Write worksheet start with frozen first row
+	b.WriteString(xlsxXMLHeader + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>` +
+		`</sheetView></sheetViews><cols>`)
+	// Write column widths
+	for i, w := range v.width {
+		n := strconv.Itoa(i + 1)
+		b.WriteString(`<col min="` + n + `" max="` + n + `" width="` + strconv.Itoa(w+2) + `" customWidth="1"/>`)
+	}
+	b.WriteString(`</cols><sheetData>`)
+	// Write header as bold strings
+	xlsxRow(&b, 1, v.header, true)
+	// Write rows
+	for i, r := range v.rows {
+		xlsxRow(&b, i+2, r, false)
+	}
+	// Write worksheet end
+	b.WriteString(`</sheetData></worksheet>`)
+	// Return worksheet
+	return b.String()
+}
+
+// xlsxRow writes row r with row number n to b. If header is true, all cells are bold strings. Otherwise,
+// cells which are decimal numbers according to xlsxNumeric are stored as numbers.
+func xlsxRow(b *strings.Builder, n int, r []string, header bool) {
+	rn := strconv.Itoa(n)
+	b.WriteString(`<row r="` + rn + `">`)
+	for i, c := range r {
+		ref := xlsxColumn(i) + rn
+		// Store the cell as a number, if it parses as a finite decimal number
+		if !header && xlsxNumeric(c) {
+			if f, e := strconv.ParseFloat(c, 64); (e == nil) && !math.IsInf(f, 0) {
+				b.WriteString(`<c r="` + ref + `"><v>` + strconv.FormatFloat(f, 'g', -1, 64) + `</v></c>`)
+				continue
+			}
+		}
+		// Skip empty string cells
+		if c == "" {
+			continue
+		}
+		// Store the cell as an inline string, bold if it is in the header
+		s := ""
+		if header {
+			s = ` s="1"`
+		}
+		b.WriteString(`<c r="` + ref + `"` + s + ` t="inlineStr"><is><t xml:space="preserve">` + xmlEscape(c) + `</t></is></c>`)
+	}
+	b.WriteString(`</row>`)
+}
+
+// xlsxNumeric returns true, if cell c is a decimal number, which keeps its value in a workbook. Numbers with a
+// leading zero in the integer part, like zip codes or 007, and numbers with more than xlsxDigits significant digits,
+// like credit card numbers, are not numeric, because a spreadsheet would drop the leading zeros or round the digits.
+// The numbers 0 and 0.5 are numeric.
+func xlsxNumeric(c string) bool {
+	// Return false, if c is not a decimal number
+	m := xlsxNumber.FindStringSubmatch(c)
+	if m == nil {
+		return false
+	}
+	// Return false, if the integer part has a leading zero
+	if (len(m[1]) > 1) && (m[1][0] == '0') {
+		return false
+	}
+	// Return false, if the mantissa has more significant digits than a workbook keeps
+	return len(strings.TrimLeft(m[1]+m[2]+m[3], "0")) <= xlsxDigits
+}
+
+// xlsxColumn returns the column name A, B, ..., Z, AA, AB, ... for the column with index i.
+func xlsxColumn(i int) string {
+	n := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		n = string(rune('A'+(i-1)%26)) + n
+	}
+	return n
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2023 thorstenrie.
+// All Rights Reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tstable_test
+
+// Import Go standard library packages as well as tstable and tserr
+import (
+	"archive/zip"  // zip
+	"bytes"        // bytes
+	"encoding/xml" // xml
+	"io"           // io
+	"testing"      // testing
+
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tstable" // tstable
+)
+
+// xlsxSheet holds the parsed cells of a worksheet
+type xlsxSheet struct {
+	Pane struct {
+		YSplit string `xml:"ySplit,attr"`
+		State  string `xml:"state,attr"`
+	} `xml:"sheetViews>sheetView>pane"`
+	Rows []struct {
+		Cells []struct {
+			Ref    string `xml:"r,attr"`
+			Style  string `xml:"s,attr"`
+			Type   string `xml:"t,attr"`
+			Value  string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// TestXLSX tests the workbook of a table with strings, numbers and number-like strings, which are kept as strings.
+// The test fails if the workbook is not a valid zip archive, if a part is missing or if the cells of the worksheet
+// do not match.
+func TestXLSX(t *testing.T) {
+	// Retrieve new test table
+	tbl, e := tstable.New([]string{"Host", "CPU", "Memory"})
+	// The test fails if New returns an error
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "New", Fn: "table", Err: e}))
+	}
+	// Add a row with strings and numbers
+	if e := tbl.AddRow([]string{"node&1", "0.75", "1e3"}); e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: "table", Err: e}))
+	}
+	// Add a row with a number-like string and an empty cell
+	if e := tbl.AddRow([]string{"node2", "0x10", ""}); e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: "table", Err: e}))
+	}
+	// Add a row with a leading zero and more significant digits than a workbook keeps
+	if e := tbl.AddRow([]string{"node3", "007", "4111111111111111"}); e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: "table", Err: e}))
+	}
+	// Add a row with zero and the maximum number of significant digits
+	if e := tbl.AddRow([]string{"node4", "0", "123456789012345"}); e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "AddRow", Fn: "table", Err: e}))
+	}
+	// Write workbook
+	var b bytes.Buffer
+	if e := tbl.XLSX(&b, nil); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "XLSX", Fn: "table", Err: e}))
+	}
+	// Open workbook as zip archive
+	z, e := zip.NewReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "NewReader", Fn: "workbook", Err: e}))
+	}
+	// Read all parts
+	parts := make(map[string][]byte)
+	for _, f := range z.File {
+		r, e := f.Open()
+		if e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Open", Fn: f.Name, Err: e}))
+		}
+		parts[f.Name], e = io.ReadAll(r)
+		if e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ReadAll", Fn: f.Name, Err: e}))
+		}
+	}
+	// The test fails if a part is missing
+	for _, p := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		if _, ok := parts[p]; !ok {
+			t.Error(tserr.NotExistent(p))
+		}
+	}
+	// Parse worksheet
+	var s xlsxSheet
+	if e := xml.Unmarshal(parts["xl/worksheets/sheet1.xml"], &s); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Unmarshal", Fn: "worksheet", Err: e}))
+	}
+	// The test fails if the first row is not frozen
+	if (s.Pane.YSplit != "1") || (s.Pane.State != "frozen") {
+		t.Error(tserr.NotSet("frozen first row"))
+	}
+	// The test fails if the number of rows does not match
+	if len(s.Rows) != 5 {
+		t.Fatal(tserr.Equal(&tserr.EqualArgs{Var: "rows", Actual: int64(len(s.Rows)), Want: 5}))
+	}
+	// Expected cells as reference, style, type and value
+	want := [][4]string{
+		{"A1", "1", "inlineStr", "Host"}, {"B1", "1", "inlineStr", "CPU"}, {"C1", "1", "inlineStr", "Memory"},
+		{"A2", "", "inlineStr", "node&1"}, {"B2", "", "", "0.75"}, {"C2", "", "", "1000"},
+		{"A3", "", "inlineStr", "node2"}, {"B3", "", "inlineStr", "0x10"},
+		{"A4", "", "inlineStr", "node3"}, {"B4", "", "inlineStr", "007"}, {"C4", "", "inlineStr", "4111111111111111"},
+		{"A5", "", "inlineStr", "node4"}, {"B5", "", "", "0"}, {"C5", "", "", "1.23456789012345e+14"},
+	}
+	// Retrieve actual cells
+	var act [][4]string
+	for _, r := range s.Rows {
+		for _, c := range r.Cells {
+			act = append(act, [4]string{c.Ref, c.Style, c.Type, c.Value + c.Inline})
+		}
+	}
+	// The test fails if the number of cells does not match
+	if len(act) != len(want) {
+		t.Fatal(tserr.Equal(&tserr.EqualArgs{Var: "cells", Actual: int64(len(act)), Want: int64(len(want))}))
+	}
+	// The test fails if a cell does not match
+	for i := range want {
+		if act[i] != want[i] {
+			t.Error(tserr.EqualStr(&tserr.EqualStrArgs{Var: "cell " + want[i][0], Actual: act[i][3], Want: want[i][3]}))
+		}
+	}
+}
+
+// TestXLSXErr tests XLSX to return an error in case of an invalid sheet name.
+// The test fails if XLSX returns a nil error.
+func TestXLSXErr(t *testing.T) {
+	// Retrieve test table
+	tbl := testTable(t)
+	// Iterate invalid sheet names
+	for _, s := range []string{"a/b", "a sheet name longer than the maximum"} {
+		// The test fails if XLSX returns a nil error
+		if e := tbl.XLSX(io.Discard, &tstable.XLSXArgs{Sheet: s}); e == nil {
+			t.Error(tserr.NilFailed("XLSX"))
+		}
+	}
+}